@@ -0,0 +1,90 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ HealthChecker = (*AsyncChecker)(nil)
+
+// AsyncChecker runs a [HealthChecker] in the background and returns its most
+// recent result.
+type AsyncChecker struct {
+	check    HealthChecker
+	interval time.Duration
+	status   AtomicStatus
+	cancelFn context.CancelFunc
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+const panicInvalidInterval = "healthcheck.Async: interval should be greater than zero"
+
+// Async wraps check in an [AsyncChecker] which immediately starts calling
+// check in a separate goroutine, and again every interval. Each call to check
+// times out after interval. Until the first call to check has completed, the
+// [AsyncChecker]'s [Status] is [StatusUnknown]. Use [AsyncChecker.Stop] to
+// stop the goroutine. This is useful for checks which are too slow to run
+// inline with probes.
+func Async(check HealthChecker, interval time.Duration) *AsyncChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	if interval <= 0 {
+		panic(panicInvalidInterval)
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	ac := &AsyncChecker{
+		check:    check,
+		interval: interval,
+		cancelFn: cancelFn,
+		done:     make(chan struct{}),
+	}
+	go ac.run(ctx)
+	return ac
+}
+
+func (ac *AsyncChecker) run(ctx context.Context) {
+	defer close(ac.done)
+
+	ticker := time.NewTicker(ac.interval)
+	defer ticker.Stop()
+
+	for {
+		checkCtx, cancelFn := context.WithTimeout(ctx, ac.interval)
+		stat := ac.check.CheckHealth(checkCtx)
+		cancelFn()
+
+		// do not overwrite the last result with the result of a check which
+		// was canceled because of Stop
+		if ctx.Err() != nil {
+			return
+		}
+		ac.status.Store(stat)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth returns the most recent [Status] of the wrapped
+// [HealthChecker] without calling it.
+func (ac *AsyncChecker) CheckHealth(context.Context) Status {
+	return ac.status.Load()
+}
+
+// Stop stops the background goroutine and waits for it to exit. It is safe to
+// call Stop multiple times.
+func (ac *AsyncChecker) Stop() {
+	ac.stopOnce.Do(ac.cancelFn)
+	<-ac.done
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsync(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		Async(nil, time.Second)
+	})
+	assert.PanicsWithValue(t, panicInvalidInterval, func() {
+		Async(HealthCheckerFunc(func(context.Context) Status { return StatusHealthy }), 0)
+	})
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	ac := Async(HealthCheckerFunc(func(context.Context) Status {
+		if calls.Add(1) == 1 {
+			<-release
+			return StatusHealthy
+		}
+		return StatusUnhealthy
+	}), 20*time.Millisecond)
+	defer ac.Stop()
+
+	ctx := context.Background()
+	assert.Equal(t, StatusUnknown, ac.CheckHealth(ctx), "should be unknown before first result")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return ac.CheckHealth(ctx) == StatusHealthy
+	}, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return ac.CheckHealth(ctx) == StatusUnhealthy
+	}, time.Second, time.Millisecond)
+
+	ac.Stop()
+	n := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, calls.Load(), "should not call check after stop")
+	ac.Stop()
+}
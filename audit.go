@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"sync"
+	"time"
+)
+
+// AuditEntry describes a transition of the combined health [Status] of a
+// [Checker].
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	OldStatus Status    `json:"old_status"`
+	Status    Status    `json:"status"`
+	// Checks contains the [Status] of each registered [HealthChecker] at the
+	// time of the transition, explaining why it occurred.
+	Checks map[string]Status `json:"checks,omitempty"`
+}
+
+const panicInvalidAuditLogSize = "healthcheck.WithAuditLog: size should be greater than zero"
+
+// WithAuditLog keeps an in-memory audit log of the last size transitions of
+// the combined health [Status] of the [Checker]. Use [Checker.AuditLog] or
+// [WithHistoryRoute] to access it.
+func WithAuditLog(size int) Option {
+	if size <= 0 {
+		panic(panicInvalidAuditLogSize)
+	}
+
+	return func(c *Checker) error {
+		c.audit = &auditLog{entries: make([]AuditEntry, 0, size)}
+		return nil
+	}
+}
+
+// AuditLog returns the entries of the audit log, oldest first. It returns nil
+// when the audit log is not enabled using [WithAuditLog].
+func (h *Checker) AuditLog() []AuditEntry {
+	if h.audit == nil {
+		return nil
+	}
+	return h.audit.list()
+}
+
+// auditLog is a bounded, in order list of [AuditEntry]. When full, the
+// oldest entry is overwritten.
+type auditLog struct {
+	mut     sync.Mutex
+	entries []AuditEntry
+	next    int
+}
+
+func (a *auditLog) add(e AuditEntry) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if len(a.entries) < cap(a.entries) {
+		a.entries = append(a.entries, e)
+		return
+	}
+
+	a.entries[a.next] = e
+	a.next = (a.next + 1) % len(a.entries)
+}
+
+func (a *auditLog) list() []AuditEntry {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	res := make([]AuditEntry, 0, len(a.entries))
+	res = append(res, a.entries[a.next:]...)
+	return append(res, a.entries[:a.next]...)
+}
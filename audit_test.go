@@ -0,0 +1,69 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithAuditLog(t *testing.T) {
+	t.Run("invalid size", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicInvalidAuditLogSize, func() {
+			_ = WithAuditLog(0)
+		})
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c, err := New(WithHealthChecker("db", &errorCheck{stat: StatusHealthy}))
+		assert.NoError(t, err)
+		c.CheckHealth(context.Background())
+		assert.Nil(t, c.AuditLog())
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		check := &errorCheck{stat: StatusHealthy}
+		c, err := New(WithAuditLog(2), WithHealthChecker("db", check))
+		assert.NoError(t, err)
+
+		for _, stat := range []Status{StatusHealthy, StatusHealthy, StatusUnhealthy, StatusHealthy} {
+			check.stat = stat
+			c.CheckHealth(context.Background())
+		}
+
+		have := c.AuditLog()
+		assert.Len(t, have, 2)
+		assert.Equal(t, StatusHealthy, have[0].OldStatus)
+		assert.Equal(t, StatusUnhealthy, have[0].Status)
+		assert.Equal(t, map[string]Status{"db": StatusUnhealthy}, have[0].Checks)
+		assert.Equal(t, StatusUnhealthy, have[1].OldStatus)
+		assert.Equal(t, StatusHealthy, have[1].Status)
+		assert.False(t, have[1].Time.Before(have[0].Time))
+	})
+}
+
+func TestWithHistoryRoute(t *testing.T) {
+	c, err := New(WithAuditLog(10), WithHealthChecker("db", &errorCheck{stat: StatusUnhealthy}))
+	assert.NoError(t, err)
+	c.CheckHealth(context.Background())
+
+	rec := httptest.NewRecorder()
+	HTTPHandler(c, WithHistoryRoute("/healthy/history")).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthy/history", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var have []AuditEntry
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &have))
+	assert.Len(t, have, 1)
+	assert.Equal(t, StatusUnhealthy, have[0].Status)
+	assert.Equal(t, map[string]Status{"db": StatusUnhealthy}, have[0].Checks)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ HealthChecker = (*CachedChecker)(nil)
+
+// CachedChecker wraps a [HealthChecker] and memoizes its result for a TTL.
+type CachedChecker struct {
+	HealthChecker
+	TTL time.Duration
+
+	mut  sync.Mutex
+	stat Status
+	time time.Time
+}
+
+// Cached wraps check in a [CachedChecker] which memoizes the [Status] returned
+// by check for ttl. This way an expensive [HealthChecker] can be registered
+// to multiple [Checker](s), or be checked frequently, without additional
+// load.
+func Cached(check HealthChecker, ttl time.Duration) *CachedChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	return &CachedChecker{
+		HealthChecker: check,
+		TTL:           ttl,
+	}
+}
+
+// CheckHealth returns the memoized [Status] when it is not older than TTL.
+// Otherwise, it calls the wrapped [HealthChecker] and memoizes its result.
+// Concurrent calls wait for a single call to the wrapped [HealthChecker].
+func (cc *CachedChecker) CheckHealth(ctx context.Context) Status {
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	if !cc.time.IsZero() && time.Since(cc.time) < cc.TTL {
+		return cc.stat
+	}
+
+	cc.stat = cc.HealthChecker.CheckHealth(ctx)
+	cc.time = time.Now()
+	return cc.stat
+}
+
+// Reset clears the memoized [Status], so the next call to CheckHealth calls
+// the wrapped [HealthChecker].
+func (cc *CachedChecker) Reset() {
+	cc.mut.Lock()
+	cc.time = time.Time{}
+	cc.mut.Unlock()
+}
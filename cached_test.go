@@ -0,0 +1,41 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCached(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		Cached(nil, time.Second)
+	})
+
+	var calls atomic.Int32
+	cc := Cached(HealthCheckerFunc(func(context.Context) Status {
+		if calls.Add(1) == 1 {
+			return StatusHealthy
+		}
+		return StatusUnhealthy
+	}), 50*time.Millisecond)
+
+	ctx := context.Background()
+	assert.Equal(t, StatusHealthy, cc.CheckHealth(ctx))
+	assert.Equal(t, StatusHealthy, cc.CheckHealth(ctx))
+	assert.Equal(t, int32(1), calls.Load(), "should use memoized status")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, StatusUnhealthy, cc.CheckHealth(ctx))
+	assert.Equal(t, int32(2), calls.Load(), "should call check after ttl")
+
+	cc.Reset()
+	assert.Equal(t, StatusUnhealthy, cc.CheckHealth(ctx))
+	assert.Equal(t, int32(3), calls.Load(), "should call check after reset")
+}
@@ -6,6 +6,7 @@ package healthcheck
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -22,6 +23,19 @@ type HealthCheckerFunc func(ctx context.Context) Status
 
 func (fn HealthCheckerFunc) CheckHealth(ctx context.Context) Status { return fn(ctx) }
 
+// Result is the outcome of a single run of a [HealthChecker] registered in a
+// [Checker].
+type Result struct {
+	Status Status
+	// Err is the error returned by the health check, if it implements a
+	// Check(ctx) (Status, error) method.
+	Err error
+	// Duration is the wall-clock duration of the health check.
+	Duration time.Duration
+	// Time is the time at which the health check started.
+	Time time.Time
+}
+
 // Registerer registers [HealthChecker](s).
 type Registerer interface {
 	Register(name string, check HealthChecker)
@@ -42,11 +56,16 @@ type Checker struct {
 	// Parallel indicates whether to run health checks in parallel.
 	Parallel bool
 
-	log      Logger
-	mut      sync.RWMutex
-	checks   map[string]HealthChecker
-	statuses map[string]Status
-	status   AtomicStatus
+	log          Logger
+	checkLogs    map[string]Logger
+	sinks        []EventSink
+	interceptors []Interceptor
+	audit        *auditLog
+	mut          sync.RWMutex
+	checks       map[string]HealthChecker
+	statuses     map[string]Status
+	results      map[string]Result
+	status       AtomicStatus
 }
 
 func New(opts ...Option) (*Checker, error) {
@@ -92,6 +111,19 @@ func (h *Checker) Statuses() map[string]Status {
 	return h.copyStatuses()
 }
 
+// Results returns a map of the [Result] of the last health check of all
+// registered [HealthChecker](s), including how long each check took.
+func (h *Checker) Results() map[string]Result {
+	h.mut.RLock()
+	defer h.mut.RUnlock()
+
+	res := make(map[string]Result, len(h.results))
+	for k, v := range h.results {
+		res[k] = v
+	}
+	return res
+}
+
 func (h *Checker) copyStatuses() map[string]Status {
 	stats := make(map[string]Status, len(h.statuses))
 	for k, v := range h.statuses {
@@ -100,6 +132,27 @@ func (h *Checker) copyStatuses() map[string]Status {
 	return stats
 }
 
+// checkLogger returns the [CheckLogger] for the [HealthChecker] registered
+// with name, or nil if there is none.
+func (h *Checker) checkLogger(name string) CheckLogger {
+	if log, ok := h.checkLogs[name]; ok {
+		cl, _ := log.(CheckLogger)
+		return cl
+	}
+	cl, _ := h.log.(CheckLogger)
+	return cl
+}
+
+// loggedStatuses returns a copy of the statuses without those of the
+// [HealthChecker](s) which have their own [Logger].
+func (h *Checker) loggedStatuses() map[string]Status {
+	stats := h.copyStatuses()
+	for name := range h.checkLogs {
+		delete(stats, name)
+	}
+	return stats
+}
+
 const panicNilHealthChecker = "healthcheck: HealthChecker should not be nil"
 
 // Register a [HealthChecker] with the given name.
@@ -132,10 +185,23 @@ func (h *Checker) Unregister(name string) {
 
 // CheckHealth triggers a health check for all registered [HealthChecker](s).
 func (h *Checker) CheckHealth(ctx context.Context) Status {
+	if len(h.interceptors) == 0 {
+		return h.checkHealth(ctx)
+	}
+
+	stat, _ := h.intercept(ctx, "", func(ctx context.Context) (Status, error) {
+		return h.checkHealth(ctx), nil
+	})
+	return stat
+}
+
+func (h *Checker) checkHealth(ctx context.Context) Status {
+	start := time.Now()
+
 	h.mut.RLock()
 	if len(h.checks) == 0 {
 		defer h.mut.RUnlock()
-		h.setStatus(StatusHealthy)
+		h.setStatus(StatusHealthy, start)
 		return StatusHealthy
 	}
 
@@ -145,32 +211,37 @@ func (h *Checker) CheckHealth(ctx context.Context) Status {
 
 	if h.statuses == nil {
 		h.statuses = make(map[string]Status, len(h.checks))
+		h.results = make(map[string]Result, len(h.checks))
 	}
 
-	if h.Timeout > 0 {
-		// add a timeout to the context if none is set
-		if t, ok := ctx.Deadline(); !ok || h.Timeout < time.Until(t) {
-			var cancelFn context.CancelFunc
-			ctx, cancelFn = context.WithTimeout(ctx, h.Timeout)
-			defer cancelFn()
-		}
-	}
+	ctx, cancelFn := h.withTimeout(ctx)
+	defer cancelFn()
 
 	// check health status for each registered service
 	if len(h.checks) == 1 || !h.Parallel {
 		for name, c := range h.checks {
-			h.statuses[name] = c.CheckHealth(ctx)
+			h.setResult(name, h.runCheck(ctx, name, c, h.statuses[name]))
 		}
 	} else {
+		var mut sync.Mutex
 		var wg sync.WaitGroup
+		results := make(map[string]Result, len(h.checks))
 		wg.Add(len(h.checks))
 		for name, c := range h.checks {
-			go func(name string, c HealthChecker) {
+			go func(name string, c HealthChecker, old Status) {
 				defer wg.Done()
-				h.statuses[name] = c.CheckHealth(ctx)
-			}(name, c)
+				res := h.runCheck(ctx, name, c, old)
+
+				mut.Lock()
+				results[name] = res
+				mut.Unlock()
+			}(name, c, h.statuses[name])
 		}
 		wg.Wait()
+
+		for name, res := range results {
+			h.setResult(name, res)
+		}
 	}
 
 	result := StatusUnknown
@@ -181,12 +252,98 @@ func (h *Checker) CheckHealth(ctx context.Context) Status {
 		}
 	}
 
-	h.setStatus(result)
+	h.setStatus(result, start)
 	return result
 }
 
-func (h *Checker) setStatus(stat Status) {
+// CheckHealthOf triggers a health check for only the [HealthChecker]
+// registered with the given name. It returns false when no [HealthChecker] is
+// registered with this name.
+func (h *Checker) CheckHealthOf(ctx context.Context, name string) (Status, bool) {
+	h.mut.RLock()
+	check, ok := h.checks[name]
+	h.mut.RUnlock()
+	if !ok {
+		return StatusUnknown, false
+	}
+
+	ctx, cancelFn := h.withTimeout(ctx)
+	defer cancelFn()
+
+	h.mut.RLock()
+	old := h.statuses[name]
+	h.mut.RUnlock()
+
+	res := h.runCheck(ctx, name, check, old)
+
+	h.mut.Lock()
+	if h.statuses == nil {
+		h.statuses = make(map[string]Status, len(h.checks))
+		h.results = make(map[string]Result, len(h.checks))
+	}
+	h.setResult(name, res)
+	h.mut.Unlock()
+	return res.Status, true
+}
+
+func (h *Checker) setResult(name string, res Result) {
+	if log, ok := h.checkLogs[name]; ok {
+		if old := h.statuses[name]; old != res.Status {
+			log.LogHealthChanged(res.Status, old, map[string]Status{name: res.Status})
+		}
+	}
+	h.statuses[name] = res.Status
+	h.results[name] = res
+}
+
+// Close closes each [EventSink] added to the [Checker] which implements
+// [io.Closer], like the ones added with [WithPublisher]. It waits until their
+// pending events are handled.
+func (h *Checker) Close() error {
+	h.mut.RLock()
+	sinks := h.sinks
+	h.mut.RUnlock()
+
+	var err error
+	for _, sink := range sinks {
+		if c, ok := sink.(io.Closer); ok {
+			err = errors.Append(err, c.Close())
+		}
+	}
+	return err
+}
+
+// withTimeout adds a timeout to the context if none is set, or when the
+// context's deadline exceeds the Checker's Timeout.
+func (h *Checker) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.Timeout > 0 {
+		if t, ok := ctx.Deadline(); !ok || h.Timeout < time.Until(t) {
+			return context.WithTimeout(ctx, h.Timeout)
+		}
+	}
+	return ctx, func() {}
+}
+
+func (h *Checker) setStatus(stat Status, start time.Time) {
 	if old := h.status.Swap(stat); old != stat {
-		h.log.LogHealthChanged(stat, old, h.copyStatuses())
+		h.log.LogHealthChanged(stat, old, h.loggedStatuses())
+		if h.audit != nil {
+			h.audit.add(AuditEntry{
+				Time:      start,
+				OldStatus: old,
+				Status:    stat,
+				Checks:    h.copyStatuses(),
+			})
+		}
+		if len(h.sinks) != 0 {
+			h.emit(Event{
+				Kind:      EventHealthChanged,
+				OldStatus: old,
+				Status:    stat,
+				Checks:    h.copyStatuses(),
+				Duration:  time.Since(start),
+				Time:      start,
+			})
+		}
 	}
 }
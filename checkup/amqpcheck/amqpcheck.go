@@ -0,0 +1,115 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package amqpcheck provides a [healthcheck.HealthChecker] which checks the
+// health of a connection to an AMQP broker, like RabbitMQ.
+package amqpcheck
+
+import (
+	"context"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	ErrConnectionClosed errors.Msg = "connection is closed"
+	ErrChannelClosed    errors.Msg = "channel is closed"
+	ErrQueueCheckFailed errors.Msg = "passive queue declare failed"
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a connection to an AMQP broker.
+type Checker struct {
+	conn        *amqp.Connection
+	channel     *amqp.Channel
+	queue       string
+	openChannel func() (queueDeclarer, error)
+}
+
+// queueDeclarer is implemented by [amqp.Channel].
+type queueDeclarer interface {
+	QueueDeclarePassive(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	Close() error
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithChannel additionally checks if ch is open.
+func WithChannel(ch *amqp.Channel) Option {
+	return func(c *Checker) { c.channel = ch }
+}
+
+// WithPassiveQueue verifies the queue with name exists by passively declaring
+// it on a new channel, which is closed afterwards.
+func WithPassiveQueue(name string) Option {
+	return func(c *Checker) { c.queue = name }
+}
+
+const panicNilConnection = "amqpcheck.New: amqp.Connection should not be nil"
+
+// New creates a [Checker] which checks the health of conn.
+func New(conn *amqp.Connection, opts ...Option) *Checker {
+	if conn == nil {
+		panic(panicNilConnection)
+	}
+
+	c := Checker{conn: conn}
+	c.openChannel = func() (queueDeclarer, error) { return conn.Channel() }
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the connection, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an error when the connection
+// or channel is closed, or when the passive queue declaration fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.conn.IsClosed() {
+		return healthcheck.StatusUnhealthy, errors.New(ErrConnectionClosed)
+	}
+	if c.channel != nil && c.channel.IsClosed() {
+		return healthcheck.StatusUnhealthy, errors.New(ErrChannelClosed)
+	}
+	if c.queue == "" {
+		return healthcheck.StatusHealthy, nil
+	}
+
+	// declaring a queue does not support a context, do not wait for it
+	// longer than ctx allows
+	done := make(chan error, 1)
+	go func() { done <- c.declarePassive() }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrQueueCheckFailed)
+		}
+		return healthcheck.StatusHealthy, nil
+
+	case <-ctx.Done():
+		return healthcheck.StatusUnknown, errors.Wrap(ctx.Err(), ErrQueueCheckFailed)
+	}
+}
+
+func (c *Checker) declarePassive() error {
+	ch, err := c.openChannel()
+	if err != nil {
+		return err
+	}
+
+	_, err = ch.QueueDeclarePassive(c.queue, false, false, false, false, nil)
+	return errors.Append(err, ch.Close())
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package amqpcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilConnection, func() {
+		_ = New(nil)
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		declare    fakeChannel
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"open": {
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"queue exists": {
+			opts:       []Option{WithPassiveQueue("jobs")},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"queue not found": {
+			declare:    fakeChannel{err: &amqp.Error{Code: amqp.NotFound, Reason: "no queue 'jobs'"}},
+			opts:       []Option{WithPassiveQueue("jobs")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrQueueCheckFailed,
+		},
+		"queue timeout": {
+			declare:    fakeChannel{delay: time.Second},
+			opts:       []Option{WithPassiveQueue("jobs")},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    context.DeadlineExceeded,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := New(new(amqp.Connection), tc.opts...)
+			c.openChannel = func() (queueDeclarer, error) { return &tc.declare, nil }
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancelFn()
+
+			stat, err := c.Check(ctx)
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+type fakeChannel struct {
+	err   error
+	delay time.Duration
+}
+
+func (ch *fakeChannel) QueueDeclarePassive(name string, _, _, _, _ bool, _ amqp.Table) (amqp.Queue, error) {
+	time.Sleep(ch.delay)
+	return amqp.Queue{Name: name}, ch.err
+}
+
+func (*fakeChannel) Close() error { return nil }
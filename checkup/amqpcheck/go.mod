@@ -0,0 +1,17 @@
+module github.com/go-pogo/healthcheck/checkup/amqpcheck
+
+go 1.20
+
+require (
+	github.com/go-pogo/errors v0.11.2
+	github.com/go-pogo/healthcheck v0.0.0-20261017203950-54cc65100655
+	github.com/rabbitmq/amqp091-go v1.15.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
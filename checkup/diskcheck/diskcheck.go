@@ -0,0 +1,142 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package diskcheck provides a [healthcheck.HealthChecker] which checks the
+// free disk space and inodes of the file system a path resides on.
+package diskcheck
+
+import (
+	"context"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrUsageFailed   errors.Msg = "get disk usage failed"
+	ErrUnsupported   errors.Msg = "disk usage not supported on this platform"
+	ErrLowDiskSpace  errors.Msg = "low disk space"
+	ErrLowFreeInodes errors.Msg = "low free inodes"
+)
+
+const (
+	// DefaultWarnPercent is the default percentage of free space below which
+	// the disk space is considered low.
+	DefaultWarnPercent = 10
+	// DefaultCritPercent is the default percentage of free space below which
+	// the disk space is considered critically low.
+	DefaultCritPercent = 5
+)
+
+// usage contains the disk usage of a file system.
+type usage struct {
+	total, free        uint64
+	inodes, inodesFree uint64
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the free disk space and inodes of a file system.
+type Checker struct {
+	path string
+
+	warnPercent, critPercent float64
+	warnBytes, critBytes     uint64
+	warnInodes, critInodes   float64
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithFreePercent sets the percentages of free disk space below which the
+// [Checker] reports [healthcheck.StatusUnknown] (warn) and
+// [healthcheck.StatusUnhealthy] (crit). The defaults are [DefaultWarnPercent]
+// and [DefaultCritPercent]. A value of zero disables the threshold.
+func WithFreePercent(warn, crit float64) Option {
+	return func(c *Checker) {
+		c.warnPercent = warn
+		c.critPercent = crit
+	}
+}
+
+// WithFreeBytes sets the number of free bytes below which the [Checker]
+// reports [healthcheck.StatusUnknown] (warn) and [healthcheck.StatusUnhealthy]
+// (crit). A value of zero disables the threshold.
+func WithFreeBytes(warn, crit uint64) Option {
+	return func(c *Checker) {
+		c.warnBytes = warn
+		c.critBytes = crit
+	}
+}
+
+// WithFreeInodesPercent sets the percentages of free inodes below which the
+// [Checker] reports [healthcheck.StatusUnknown] (warn) and
+// [healthcheck.StatusUnhealthy] (crit). A value of zero disables the
+// threshold. File systems without inodes are not checked.
+func WithFreeInodesPercent(warn, crit float64) Option {
+	return func(c *Checker) {
+		c.warnInodes = warn
+		c.critInodes = crit
+	}
+}
+
+// New creates a [Checker] which checks the file system path resides on.
+func New(path string, opts ...Option) *Checker {
+	c := Checker{
+		path:        path,
+		warnPercent: DefaultWarnPercent,
+		critPercent: DefaultCritPercent,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the free disk space and inodes, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an error when the free disk
+// space or inodes are below any of the critical thresholds, or
+// [healthcheck.StatusUnknown] and an error when below any of the warning
+// thresholds.
+func (c *Checker) Check(context.Context) (healthcheck.Status, error) {
+	u, err := diskUsage(c.path)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrUsageFailed)
+	}
+	return c.status(u)
+}
+
+func (c *Checker) status(u usage) (healthcheck.Status, error) {
+	freePercent := percent(u.free, u.total)
+	inodesPercent := percent(u.inodesFree, u.inodes)
+
+	switch {
+	case below(freePercent, c.critPercent), c.critBytes > 0 && u.free < c.critBytes:
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrLowDiskSpace, "%d bytes (%.1f%%) free", u.free, freePercent)
+	case u.inodes > 0 && below(inodesPercent, c.critInodes):
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrLowFreeInodes, "%d inodes (%.1f%%) free", u.inodesFree, inodesPercent)
+	case below(freePercent, c.warnPercent), c.warnBytes > 0 && u.free < c.warnBytes:
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrLowDiskSpace, "%d bytes (%.1f%%) free", u.free, freePercent)
+	case u.inodes > 0 && below(inodesPercent, c.warnInodes):
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrLowFreeInodes, "%d inodes (%.1f%%) free", u.inodesFree, inodesPercent)
+	default:
+		return healthcheck.StatusHealthy, nil
+	}
+}
+
+func percent(part, total uint64) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(part) / float64(total) * 100
+}
+
+func below(val, threshold float64) bool { return threshold > 0 && val < threshold }
@@ -0,0 +1,92 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package diskcheck
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("temp dir", func(t *testing.T) {
+		_, err := New(t.TempDir(), WithFreePercent(0, 0)).Check(context.Background())
+		assert.NoError(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		stat, err := New(filepath.Join(t.TempDir(), "missing")).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnknown, stat)
+		assert.ErrorIs(t, err, ErrUsageFailed)
+	})
+}
+
+func TestChecker_status(t *testing.T) {
+	const gb = 1 << 30
+
+	tests := map[string]struct {
+		usage      usage
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"plenty": {
+			usage:      usage{total: 100 * gb, free: 50 * gb},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"low percent": {
+			usage:      usage{total: 100 * gb, free: 8 * gb},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrLowDiskSpace,
+		},
+		"critical percent": {
+			usage:      usage{total: 100 * gb, free: 2 * gb},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrLowDiskSpace,
+		},
+		"low bytes": {
+			usage:      usage{total: 100 * gb, free: 20 * gb},
+			opts:       []Option{WithFreeBytes(25*gb, 10*gb)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrLowDiskSpace,
+		},
+		"critical bytes": {
+			usage:      usage{total: 100 * gb, free: 20 * gb},
+			opts:       []Option{WithFreeBytes(50*gb, 25*gb)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrLowDiskSpace,
+		},
+		"low inodes": {
+			usage:      usage{total: 100 * gb, free: 50 * gb, inodes: 1000, inodesFree: 80},
+			opts:       []Option{WithFreeInodesPercent(10, 5)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrLowFreeInodes,
+		},
+		"critical inodes": {
+			usage:      usage{total: 100 * gb, free: 50 * gb, inodes: 1000, inodesFree: 10},
+			opts:       []Option{WithFreeInodesPercent(10, 5)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrLowFreeInodes,
+		},
+		"no inodes": {
+			usage:      usage{total: 100 * gb, free: 50 * gb},
+			opts:       []Option{WithFreeInodesPercent(10, 5)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New("", tc.opts...).status(tc.usage)
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin && !freebsd && !windows
+
+package diskcheck
+
+import "github.com/go-pogo/errors"
+
+func diskUsage(string) (usage, error) {
+	return usage{}, errors.New(ErrUnsupported)
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux || darwin || freebsd
+
+package diskcheck
+
+import (
+	"syscall"
+
+	"github.com/go-pogo/errors"
+)
+
+func diskUsage(path string) (usage, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return usage{}, errors.WithStack(err)
+	}
+
+	bsize := uint64(st.Bsize)
+	return usage{
+		total:      uint64(st.Blocks) * bsize,
+		free:       uint64(st.Bavail) * bsize,
+		inodes:     uint64(st.Files),
+		inodesFree: uint64(st.Ffree),
+	}, nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package diskcheck
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/go-pogo/errors"
+)
+
+var getDiskFreeSpaceEx = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+func diskUsage(path string) (usage, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return usage{}, errors.WithStack(err)
+	}
+
+	var u usage
+	var totalFree uint64
+	ret, _, err := getDiskFreeSpaceEx.Call(
+		uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(&u.free)),
+		uintptr(unsafe.Pointer(&u.total)),
+		uintptr(unsafe.Pointer(&totalFree)),
+	)
+	if ret == 0 {
+		return usage{}, errors.WithStack(err)
+	}
+	return u, nil
+}
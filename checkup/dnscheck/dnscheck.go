@@ -0,0 +1,102 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package dnscheck provides a [healthcheck.HealthChecker] which checks if a
+// host name can be resolved. This catches broken (cluster) dns before
+// application traffic fails.
+package dnscheck
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrLookupFailed errors.Msg = "lookup failed"
+	ErrNotFound     errors.Msg = "host not found"
+	ErrTimeout      errors.Msg = "lookup timed out"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks if a host name can be resolved.
+type Checker struct {
+	host     string
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithResolver sets the [net.Resolver] used to resolve the host name. The
+// default is [net.DefaultResolver].
+func WithResolver(r *net.Resolver) Option {
+	return func(c *Checker) { c.resolver = r }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks if host can be resolved.
+func New(host string, opts ...Option) *Checker {
+	c := Checker{
+		host:    host,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	if c.resolver == nil {
+		c.resolver = net.DefaultResolver
+	}
+	return &c
+}
+
+// CheckHealth checks if the host name can be resolved, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check resolves the host name and returns [healthcheck.StatusUnhealthy] and
+// an error when the host is not found, the lookup times out or otherwise
+// fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	addrs, err := c.resolver.LookupHost(ctx, c.host)
+	if err != nil {
+		var dnsErr *net.DNSError
+		switch {
+		case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrNotFound)
+		case errors.As(err, &dnsErr) && dnsErr.IsTimeout,
+			errors.Is(err, context.DeadlineExceeded):
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrTimeout)
+		default:
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrLookupFailed)
+		}
+	}
+	if len(addrs) == 0 {
+		return healthcheck.StatusUnhealthy, errors.New(ErrNotFound)
+	}
+	return healthcheck.StatusHealthy, nil
+}
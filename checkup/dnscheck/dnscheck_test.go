@@ -0,0 +1,60 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dnscheck
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("ip address", func(t *testing.T) {
+		stat, err := New("127.0.0.1").Check(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err)
+	})
+
+	if runtime.GOOS == "windows" {
+		t.Skip("custom Dial of net.Resolver is not used on windows")
+	}
+
+	tests := map[string]struct {
+		dialErr error
+		wait    bool
+		wantErr error
+	}{
+		"failure": {dialErr: errors.New("network is unreachable"), wantErr: ErrLookupFailed},
+		"timeout": {wait: true, wantErr: ErrTimeout},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			resolver := &net.Resolver{
+				PreferGo: true,
+				Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					if tc.wait {
+						<-ctx.Done()
+						return nil, ctx.Err()
+					}
+					return nil, tc.dialErr
+				},
+			}
+
+			stat, err := New("service.cluster.local",
+				WithResolver(resolver),
+				WithTimeout(50*time.Millisecond),
+			).Check(context.Background())
+
+			assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package elasticcheck provides a [healthcheck.HealthChecker] which checks the
+// health of an Elasticsearch or OpenSearch cluster using its _cluster/health
+// api.
+package elasticcheck
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrInvalidURL           errors.Msg = "invalid url"
+	ErrRequestFailed        errors.Msg = "request failed"
+	ErrUnexpectedStatusCode errors.Msg = "unexpected status code"
+	ErrInvalidResponse      errors.Msg = "invalid response"
+	ErrClusterYellow        errors.Msg = "cluster status is yellow"
+	ErrClusterRed           errors.Msg = "cluster status is red"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+// maxBodySize is the maximum number of bytes read from a response body.
+const maxBodySize = 1 << 20
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of an Elasticsearch or OpenSearch cluster.
+type Checker struct {
+	url           *urlpkg.URL
+	httpClient    *http.Client
+	username      string
+	password      string
+	yellowHealthy bool
+	timeout       time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithHTTPClient sets the [http.Client] used to perform requests. The default
+// is [http.DefaultClient].
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Checker) { c.httpClient = client }
+}
+
+// WithBasicAuth sets the username and password used to authenticate requests.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Checker) {
+		c.username, c.password = username, password
+	}
+}
+
+// WithYellowHealthy treats a yellow cluster status as healthy. This is useful
+// for single node clusters, which cannot allocate replica shards and thus
+// never become green.
+func WithYellowHealthy() Option {
+	return func(c *Checker) { c.yellowHealthy = true }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the health of the cluster at baseURL,
+// e.g. "http://localhost:9200". It returns an error wrapping [ErrInvalidURL]
+// when baseURL is not an absolute url.
+func New(baseURL string, opts ...Option) (*Checker, error) {
+	url, err := urlpkg.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidURL)
+	}
+	if url.Scheme == "" || url.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidURL, "url %q must be absolute", baseURL)
+	}
+
+	c := Checker{
+		url:     url.JoinPath("_cluster", "health"),
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c, nil
+}
+
+// CheckHealth checks the health of the cluster, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check requests the cluster's health and maps its status green, yellow and
+// red to respectively [healthcheck.StatusHealthy], [healthcheck.StatusUnknown]
+// and [healthcheck.StatusUnhealthy]. A yellow status is mapped to
+// [healthcheck.StatusHealthy] when [WithYellowHealthy] is used. It returns
+// [healthcheck.StatusUnhealthy] and an error when the request fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrRequestFailed)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrUnexpectedStatusCode, "status code %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxBodySize)).Decode(&body); err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrInvalidResponse)
+	}
+	return c.status(body.Status)
+}
+
+func (c *Checker) status(clusterStatus string) (healthcheck.Status, error) {
+	switch clusterStatus {
+	case "green":
+		return healthcheck.StatusHealthy, nil
+	case "yellow":
+		if c.yellowHealthy {
+			return healthcheck.StatusHealthy, nil
+		}
+		return healthcheck.StatusUnknown, errors.New(ErrClusterYellow)
+	case "red":
+		return healthcheck.StatusUnhealthy, errors.New(ErrClusterRed)
+	default:
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrInvalidResponse, "unknown cluster status %q", clusterStatus)
+	}
+}
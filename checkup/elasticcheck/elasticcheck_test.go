@@ -0,0 +1,88 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elasticcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New("localhost:9200")
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		code       int
+		body       string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"green": {
+			body:       `{"cluster_name":"test","status":"green"}`,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"yellow": {
+			body:       `{"status":"yellow"}`,
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrClusterYellow,
+		},
+		"yellow healthy": {
+			body:       `{"status":"yellow"}`,
+			opts:       []Option{WithYellowHealthy()},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"red": {
+			body:       `{"status":"red"}`,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrClusterRed,
+		},
+		"unauthorized": {
+			code:       http.StatusUnauthorized,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedStatusCode,
+		},
+		"invalid body": {
+			body:       `<html>`,
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrInvalidResponse,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/_cluster/health", req.URL.Path)
+				user, pass, _ := req.BasicAuth()
+				assert.Equal(t, "elastic", user)
+				assert.Equal(t, "secret", pass)
+
+				if tc.code != 0 {
+					wri.WriteHeader(tc.code)
+					return
+				}
+				_, _ = wri.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, append(tc.opts, WithBasicAuth("elastic", "secret"))...)
+			assert.NoError(t, err)
+
+			stat, err := c.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package execcheck provides a [healthcheck.HealthChecker] which runs an
+// external command, like an existing shell health script, and maps its exit
+// code to a [healthcheck.Status].
+package execcheck
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrStartFailed   errors.Msg = "failed to start command"
+	ErrExitUnhealthy errors.Msg = "command exited with code 1"
+	ErrExitCode      errors.Msg = "command exited with unexpected code"
+	ErrCommandFailed errors.Msg = "command failed"
+)
+
+// DefaultTimeout is the default timeout of a [Checker].
+const DefaultTimeout = 10 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker runs a command and checks its exit code.
+type Checker struct {
+	name    string
+	args    []string
+	dir     string
+	env     []string
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithDir sets the working directory of the command.
+func WithDir(dir string) Option {
+	return func(c *Checker) { c.dir = dir }
+}
+
+// WithEnv sets the environment of the command, each entry of form
+// "key=value". When not set, the command inherits the environment of the
+// current process.
+func WithEnv(env ...string) Option {
+	return func(c *Checker) { c.env = env }
+}
+
+// WithTimeout sets the timeout of the command, which is killed when the
+// timeout exceeds. A timeout of zero or less means no timeout other than
+// the deadline of the context passed to [Checker.CheckHealth].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+const panicEmptyName = "execcheck.New: name should not be empty"
+
+// New creates a [Checker] which runs the named program with the given
+// arguments. The default timeout is [DefaultTimeout].
+func New(name string, args []string, opts ...Option) *Checker {
+	if name == "" {
+		panic(panicEmptyName)
+	}
+
+	c := Checker{
+		name:    name,
+		args:    args,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth runs the command, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check runs the command and waits for it to exit. An exit code of 0 results
+// in [healthcheck.StatusHealthy] and an exit code of 1 in
+// [healthcheck.StatusUnhealthy]. Any other exit code, a failure to start the
+// command, or a timeout results in [healthcheck.StatusUnknown] and an error.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	cmd := exec.CommandContext(ctx, c.name, c.args...)
+	cmd.Dir = c.dir
+	cmd.Env = c.env
+
+	err := cmd.Run()
+	if err == nil {
+		return healthcheck.StatusHealthy, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(ctxErr, ErrCommandFailed)
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrStartFailed)
+	}
+	code := exitErr.ExitCode()
+	if code == 1 {
+		return healthcheck.StatusUnhealthy, errors.New(ErrExitUnhealthy)
+	}
+	return healthcheck.StatusUnknown, errors.Wrapf(ErrExitCode, "exit code %d", code)
+}
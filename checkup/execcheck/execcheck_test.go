@@ -0,0 +1,87 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package execcheck
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+const helperEnv = "EXECCHECK_HELPER_EXIT"
+
+// TestHelperProcess is not a real test, it is used as the command run by the
+// [Checker] in TestChecker_Check.
+func TestHelperProcess(t *testing.T) {
+	v := os.Getenv(helperEnv)
+	if v == "" {
+		return
+	}
+	if v == "sleep" {
+		time.Sleep(10 * time.Second)
+	}
+	code, _ := strconv.Atoi(v)
+	os.Exit(code)
+}
+
+func helper(exit string, opts ...Option) *Checker {
+	opts = append(opts, WithEnv(helperEnv+"="+exit))
+	return New(os.Args[0], []string{"-test.run=TestHelperProcess"}, opts...)
+}
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicEmptyName, func() {
+		New("", nil)
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		checker    *Checker
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"exit 0": {
+			checker:    helper("0"),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"exit 1": {
+			checker:    helper("1"),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrExitUnhealthy,
+		},
+		"exit 3": {
+			checker:    helper("3"),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrExitCode,
+		},
+		"timeout": {
+			checker:    helper("sleep", WithTimeout(100*time.Millisecond)),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    context.DeadlineExceeded,
+		},
+		"not found": {
+			checker:    New("execcheck-does-not-exist", nil),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrStartFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := tc.checker.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package filecheck provides a [healthcheck.HealthChecker] which checks that
+// required paths exist and are readable or writable, or that sentinel files,
+// like a "maintenance" flag file, are absent.
+package filecheck
+
+import (
+	"context"
+	"io/fs"
+	"os"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrNotExist    errors.Msg = "path does not exist"
+	ErrNotReadable errors.Msg = "path is not readable"
+	ErrNotWritable errors.Msg = "path is not writable"
+	ErrPresent     errors.Msg = "path is present"
+)
+
+type condition struct {
+	path  string
+	check func(path string) error
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks conditions of paths.
+type Checker struct {
+	conds []condition
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithExists requires all paths to exist.
+func WithExists(paths ...string) Option {
+	return withCondition(paths, exists)
+}
+
+// WithReadable requires all paths to exist and be readable.
+func WithReadable(paths ...string) Option {
+	return withCondition(paths, readable)
+}
+
+// WithWritable requires all paths to exist and be writable. A directory is
+// writable when a temporary file can be created in it.
+func WithWritable(paths ...string) Option {
+	return withCondition(paths, writable)
+}
+
+// WithAbsent requires all paths to not exist, eg. a "maintenance" flag file.
+func WithAbsent(paths ...string) Option {
+	return withCondition(paths, absent)
+}
+
+func withCondition(paths []string, check func(string) error) Option {
+	return func(c *Checker) {
+		for _, path := range paths {
+			c.conds = append(c.conds, condition{path: path, check: check})
+		}
+	}
+}
+
+// New creates a [Checker] which checks the conditions set using the provided
+// [Option](s).
+func New(opts ...Option) *Checker {
+	var c Checker
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the conditions, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an error for each unmet
+// condition.
+func (c *Checker) Check(context.Context) (healthcheck.Status, error) {
+	var err error
+	for _, cond := range c.conds {
+		err = errors.Append(err, cond.check(cond.path))
+	}
+	if err != nil {
+		return healthcheck.StatusUnhealthy, err
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func exists(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return errors.Wrapf(errors.Wrap(err, ErrNotExist), "path %q", path)
+	}
+	return nil
+}
+
+func readable(path string) error {
+	if err := exists(path); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(errors.Wrap(err, ErrNotReadable), "path %q", path)
+	}
+	_ = f.Close()
+	return nil
+}
+
+func writable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(errors.Wrap(err, ErrNotExist), "path %q", path)
+	}
+
+	if info.IsDir() {
+		var f *os.File
+		if f, err = os.CreateTemp(path, ".healthcheck-*"); err == nil {
+			_ = f.Close()
+			err = os.Remove(f.Name())
+		}
+	} else {
+		var f *os.File
+		if f, err = os.OpenFile(path, os.O_WRONLY, 0); err == nil {
+			err = f.Close()
+		}
+	}
+	if err != nil {
+		return errors.Wrapf(errors.Wrap(err, ErrNotWritable), "path %q", path)
+	}
+	return nil
+}
+
+func absent(path string) error {
+	_, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(errors.WithStack(err), "path %q", path)
+	}
+	return errors.Wrapf(ErrPresent, "path %q", path)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filecheck
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yml")
+	assert.NoError(t, os.WriteFile(file, []byte("foo: bar"), 0o600))
+	missing := filepath.Join(dir, "missing")
+
+	tests := map[string]struct {
+		opts     []Option
+		wantErrs []error
+	}{
+		"exists":     {opts: []Option{WithExists(dir, file)}},
+		"not exists": {opts: []Option{WithExists(missing)}, wantErrs: []error{ErrNotExist}},
+		"readable":   {opts: []Option{WithReadable(dir, file)}},
+		"writable":   {opts: []Option{WithWritable(dir, file)}},
+		"absent":     {opts: []Option{WithAbsent(missing)}},
+		"present":    {opts: []Option{WithAbsent(file)}, wantErrs: []error{ErrPresent}},
+		"all conditions": {
+			opts:     []Option{WithReadable(missing), WithAbsent(file)},
+			wantErrs: []error{ErrNotExist, ErrPresent},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.opts...).Check(context.Background())
+			if len(tc.wantErrs) == 0 {
+				assert.Equal(t, healthcheck.StatusHealthy, stat)
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+			for _, wantErr := range tc.wantErrs {
+				assert.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+
+	t.Run("no files left behind", func(t *testing.T) {
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gccheck provides a [healthcheck.HealthChecker] which checks the
+// pressure of the garbage collector on the current process. It is useful for
+// latency sensitive services.
+package gccheck
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrMaxPauseExceeded       errors.Msg = "gc pause exceeds max"
+	ErrMaxCPUFractionExceeded errors.Msg = "gc cpu fraction exceeds max"
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the pause durations and cpu usage of the garbage collector.
+// Without any thresholds set, it always reports [healthcheck.StatusHealthy].
+type Checker struct {
+	maxPause       time.Duration
+	maxCPUFraction float64
+
+	mut       sync.Mutex
+	lastNumGC uint32
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithMaxPause reports a garbage collection pause longer than max, which
+// occurred since the previous check, as [healthcheck.StatusUnknown] together
+// with an [ErrMaxPauseExceeded] error. The first check considers the most
+// recent 256 pauses.
+func WithMaxPause(max time.Duration) Option {
+	return func(c *Checker) { c.maxPause = max }
+}
+
+// WithMaxCPUFraction reports a fraction of the available cpu time used by the
+// garbage collector, since the program started, which exceeds max as
+// [healthcheck.StatusUnknown] together with an [ErrMaxCPUFractionExceeded]
+// error. See [runtime.MemStats.GCCPUFraction].
+func WithMaxCPUFraction(max float64) Option {
+	return func(c *Checker) { c.maxCPUFraction = max }
+}
+
+// New creates a [Checker] which checks the garbage collector against the
+// thresholds set using the provided [Option](s).
+func New(opts ...Option) *Checker {
+	var c Checker
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the garbage collector, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnknown] and an error when the garbage
+// collector exceeds any of the thresholds.
+func (c *Checker) Check(context.Context) (healthcheck.Status, error) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return c.status(&ms)
+}
+
+func (c *Checker) status(ms *runtime.MemStats) (healthcheck.Status, error) {
+	c.mut.Lock()
+	lastNumGC := c.lastNumGC
+	c.lastNumGC = ms.NumGC
+	c.mut.Unlock()
+
+	if c.maxPause > 0 {
+		n := ms.NumGC - lastNumGC
+		if n > uint32(len(ms.PauseNs)) {
+			n = uint32(len(ms.PauseNs))
+		}
+
+		// PauseNs is a circular buffer, the most recent pause is at
+		// PauseNs[(NumGC+255)%256]
+		for i := uint32(0); i < n; i++ {
+			pause := time.Duration(ms.PauseNs[(ms.NumGC-i+255)%uint32(len(ms.PauseNs))])
+			if pause > c.maxPause {
+				return healthcheck.StatusUnknown, errors.Wrapf(ErrMaxPauseExceeded, "gc paused for %s", pause)
+			}
+		}
+	}
+	if c.maxCPUFraction > 0 && ms.GCCPUFraction > c.maxCPUFraction {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrMaxCPUFractionExceeded, "gc cpu fraction is %.4f", ms.GCCPUFraction)
+	}
+	return healthcheck.StatusHealthy, nil
+}
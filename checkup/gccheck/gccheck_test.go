@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gccheck
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	runtime.GC()
+	stat, err := New(WithMaxPause(time.Minute), WithMaxCPUFraction(1)).Check(context.Background())
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+}
+
+func TestChecker_status(t *testing.T) {
+	c := New(WithMaxPause(time.Millisecond), WithMaxCPUFraction(0.1))
+
+	var ms runtime.MemStats
+	ms.NumGC = 2
+	ms.PauseNs[0] = uint64(100 * time.Microsecond)
+	ms.PauseNs[1] = uint64(5 * time.Millisecond)
+
+	stat, err := c.status(&ms)
+	assert.Equal(t, healthcheck.StatusUnknown, stat)
+	assert.ErrorIs(t, err, ErrMaxPauseExceeded)
+
+	// no new pauses since the previous check
+	stat, err = c.status(&ms)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+
+	ms.NumGC = 3
+	ms.PauseNs[2] = uint64(200 * time.Microsecond)
+	ms.GCCPUFraction = 0.25
+
+	stat, err = c.status(&ms)
+	assert.Equal(t, healthcheck.StatusUnknown, stat)
+	assert.ErrorIs(t, err, ErrMaxCPUFractionExceeded)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package goroutinecheck provides a [healthcheck.HealthChecker] which checks
+// the number of goroutines. It is a cheap early warning for goroutine leaks.
+package goroutinecheck
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrMaxExceeded        errors.Msg = "number of goroutines exceeds max"
+	ErrGrowthRateExceeded errors.Msg = "goroutine growth rate exceeds max"
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the number of goroutines.
+type Checker struct {
+	max       int
+	maxGrowth float64
+
+	mut      sync.Mutex
+	lastNum  int
+	lastTime time.Time
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithMaxGrowthRate reports a number of goroutines which grows faster than
+// perSecond goroutines per second, measured between two consecutive checks,
+// as [healthcheck.StatusUnknown] together with an [ErrGrowthRateExceeded]
+// error.
+func WithMaxGrowthRate(perSecond float64) Option {
+	return func(c *Checker) { c.maxGrowth = perSecond }
+}
+
+// New creates a [Checker] which reports [healthcheck.StatusUnhealthy] when
+// the number of goroutines exceeds max.
+func New(max int, opts ...Option) *Checker {
+	c := Checker{max: max}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the number of goroutines, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an [ErrMaxExceeded] error
+// when the number of goroutines exceeds the max provided to [New].
+func (c *Checker) Check(context.Context) (healthcheck.Status, error) {
+	return c.status(runtime.NumGoroutine(), time.Now())
+}
+
+func (c *Checker) status(num int, now time.Time) (healthcheck.Status, error) {
+	c.mut.Lock()
+	lastNum, lastTime := c.lastNum, c.lastTime
+	c.lastNum, c.lastTime = num, now
+	c.mut.Unlock()
+
+	if c.max > 0 && num > c.max {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrMaxExceeded, "%d goroutines", num)
+	}
+	if c.maxGrowth <= 0 || lastTime.IsZero() {
+		return healthcheck.StatusHealthy, nil
+	}
+
+	elapsed := now.Sub(lastTime).Seconds()
+	if elapsed <= 0 {
+		return healthcheck.StatusHealthy, nil
+	}
+	if rate := float64(num-lastNum) / elapsed; rate > c.maxGrowth {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrGrowthRateExceeded, "%.1f goroutines per second", rate)
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package goroutinecheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	stat, err := New(100_000).Check(context.Background())
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+
+	stat, err = New(1).Check(context.Background())
+	assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+	assert.ErrorIs(t, err, ErrMaxExceeded)
+}
+
+func TestWithMaxGrowthRate(t *testing.T) {
+	c := New(1000, WithMaxGrowthRate(10))
+	now := time.Now()
+
+	stat, err := c.status(100, now)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+
+	stat, err = c.status(105, now.Add(time.Second))
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+
+	stat, err = c.status(150, now.Add(2*time.Second))
+	assert.Equal(t, healthcheck.StatusUnknown, stat)
+	assert.ErrorIs(t, err, ErrGrowthRateExceeded)
+
+	stat, err = c.status(2000, now.Add(3*time.Second))
+	assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+	assert.ErrorIs(t, err, ErrMaxExceeded)
+}
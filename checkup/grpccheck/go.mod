@@ -0,0 +1,24 @@
+module github.com/go-pogo/healthcheck/checkup/grpccheck
+
+go 1.25.0
+
+require (
+	github.com/go-pogo/errors v0.11.2
+	github.com/go-pogo/healthcheck v0.0.0-20261017203950-54cc65100655
+	github.com/go-pogo/healthcheck/healthclient/grpcclient v0.0.0-20261017204125-a4b48ad30dd4
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/grpc v1.84.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-pogo/easytls v0.1.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,92 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpccheck provides a [healthcheck.HealthChecker] which checks the
+// health of an upstream gRPC service using the gRPC health checking protocol
+// (grpc.health.v1).
+package grpccheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient/grpcclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const ErrCheckFailed errors.Msg = "health check failed"
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of an upstream gRPC service.
+type Checker struct {
+	client  healthpb.HealthClient
+	service string
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithService sets the name of the service to check. An empty name checks
+// the overall health of the upstream server.
+func WithService(name string) Option {
+	return func(c *Checker) { c.service = name }
+}
+
+// WithTimeout sets the maximum duration of a single health check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+const panicNilConn = "grpccheck.New: grpc.ClientConn should not be nil"
+
+// New creates a [Checker] which checks the health of the upstream service
+// conn is connected to. The [grpc.ClientConn] is not closed by the [Checker].
+func New(conn *grpc.ClientConn, opts ...Option) *Checker {
+	if conn == nil {
+		panic(panicNilConn)
+	}
+
+	c := Checker{client: healthpb.NewHealthClient(conn)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the upstream service, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check performs a grpc.health.v1 Check request and returns the
+// [healthcheck.Status] based on the serving status in the response, see
+// [grpcclient.ServingStatus]. When the request fails because the upstream
+// service is unavailable, [healthcheck.StatusUnhealthy] is returned. Any
+// other failure results in [healthcheck.StatusUnknown].
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	resp, err := c.client.Check(ctx, &healthpb.HealthCheckRequest{Service: c.service})
+	if err != nil {
+		if status.Code(err) == codes.Unavailable {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrCheckFailed)
+		}
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrCheckFailed)
+	}
+	return grpcclient.ServingStatus(resp.GetStatus()), nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpccheck
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilConn, func() {
+		_ = New(nil)
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("bar", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	tests := map[string]struct {
+		service    string
+		wantStatus healthcheck.Status
+		wantErr    bool
+	}{
+		"server":          {service: "", wantStatus: healthcheck.StatusHealthy},
+		"serving":         {service: "foo", wantStatus: healthcheck.StatusHealthy},
+		"not serving":     {service: "bar", wantStatus: healthcheck.StatusUnhealthy},
+		"unknown service": {service: "baz", wantStatus: healthcheck.StatusUnknown, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(conn, WithService(tc.service)).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrCheckFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("unavailable", func(t *testing.T) {
+		conn, err := grpc.NewClient("127.0.0.1:1", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		stat, err := New(conn).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrCheckFailed)
+	})
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package k8scheck provides a [healthcheck.HealthChecker] which checks the
+// readiness of a Kubernetes API server using its /readyz endpoint. It is
+// useful for controllers and operators whose core dependency is the API
+// server itself.
+package k8scheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	urlpkg "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrInvalidURL    errors.Msg = "invalid url"
+	ErrNotInCluster  errors.Msg = "not running in a kubernetes cluster"
+	ErrLoadFailed    errors.Msg = "failed to load in-cluster config"
+	ErrRequestFailed errors.Msg = "request failed"
+	ErrNotReady      errors.Msg = "api server is not ready"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+// serviceAccountDir is the directory where the service account's token and
+// CA certificate are mounted in a pod.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the readiness of a Kubernetes API server.
+type Checker struct {
+	url        *urlpkg.URL
+	httpClient *http.Client
+	token      string
+	tokenFile  string
+	timeout    time.Duration
+}
+
+// Option is an option for a [Checker] created with [New] or [InCluster].
+type Option func(c *Checker)
+
+// WithHTTPClient sets the [http.Client] used to perform requests. The default
+// is [http.DefaultClient] for a [Checker] created with [New], and a client
+// which trusts the cluster's CA for a [Checker] created with [InCluster].
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Checker) { c.httpClient = client }
+}
+
+// WithToken sets the bearer token used to authenticate requests.
+func WithToken(token string) Option {
+	return func(c *Checker) {
+		c.token, c.tokenFile = token, ""
+	}
+}
+
+// WithTokenFile reads the bearer token used to authenticate requests from
+// path. The file is read on each check, so rotated tokens are picked up.
+func WithTokenFile(path string) Option {
+	return func(c *Checker) {
+		c.token, c.tokenFile = "", path
+	}
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the readiness of the API server at
+// host, e.g. "https://kubernetes.default.svc". It returns an error wrapping
+// [ErrInvalidURL] when host is not an absolute url.
+func New(host string, opts ...Option) (*Checker, error) {
+	url, err := urlpkg.Parse(host)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidURL)
+	}
+	if url.Scheme == "" || url.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidURL, "url %q must be absolute", host)
+	}
+
+	c := Checker{
+		url:     url.JoinPath("readyz"),
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c, nil
+}
+
+// InCluster creates a [Checker] which checks the readiness of the API server
+// of the cluster the current pod is running in, authenticated using the pod's
+// service account. It returns an error wrapping [ErrNotInCluster] when not
+// running in a pod, or [ErrLoadFailed] when the service account's CA
+// certificate cannot be loaded.
+func InCluster(opts ...Option) (*Checker, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New(ErrNotInCluster)
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, errors.Wrap(err, ErrLoadFailed)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.Wrap(ErrLoadFailed, "no certificates found in ca.crt")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	opts = append([]Option{
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithTokenFile(filepath.Join(serviceAccountDir, "token")),
+	}, opts...)
+	return New("https://"+net.JoinHostPort(host, port), opts...)
+}
+
+// CheckHealth checks the readiness of the API server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check requests the /readyz endpoint of the API server. It returns
+// [healthcheck.StatusUnhealthy] and an error when the request fails or the
+// API server is not ready.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+
+	token := c.token
+	if c.tokenFile != "" {
+		b, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return healthcheck.StatusUnknown, errors.Wrap(err, ErrLoadFailed)
+		}
+		token = strings.TrimSpace(string(b))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrRequestFailed)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrNotReady, "status code %d", resp.StatusCode)
+	}
+	return healthcheck.StatusHealthy, nil
+}
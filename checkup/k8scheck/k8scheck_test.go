@@ -0,0 +1,106 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package k8scheck
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New("kubernetes.default.svc")
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		code       int
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"ready": {
+			code:       http.StatusOK,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"not ready": {
+			code:       http.StatusInternalServerError,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNotReady,
+		},
+		"unauthorized": {
+			code:       http.StatusUnauthorized,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNotReady,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/readyz", req.URL.Path)
+				assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+				wri.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, WithToken("token"))
+			assert.NoError(t, err)
+
+			stat, err := c.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestInCluster(t *testing.T) {
+	t.Run("not in cluster", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		_, err := InCluster()
+		assert.ErrorIs(t, err, ErrNotInCluster)
+	})
+
+	t.Run("in cluster", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+			assert.Equal(t, "/readyz", req.URL.Path)
+			assert.Equal(t, "Bearer rotated", req.Header.Get("Authorization"))
+		}))
+		defer srv.Close()
+
+		dir := t.TempDir()
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), ca, 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("initial\n"), 0o600))
+
+		defer func(prev string) { serviceAccountDir = prev }(serviceAccountDir)
+		serviceAccountDir = dir
+
+		u, _ := url.Parse(srv.URL)
+		host, port, _ := net.SplitHostPort(u.Host)
+		t.Setenv("KUBERNETES_SERVICE_HOST", host)
+		t.Setenv("KUBERNETES_SERVICE_PORT", port)
+
+		c, err := InCluster()
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("rotated\n"), 0o600))
+		stat, err := c.Check(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package kafkacheck provides a [healthcheck.HealthChecker] which checks the
+// health of a Kafka cluster. It does not depend on a specific Kafka client,
+// any client can be used by implementing [MetadataFetcher] or wrapping it in
+// a [MetadataFetcherFunc].
+package kafkacheck
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrMetadataFailed errors.Msg = "fetch metadata failed"
+	ErrNoBrokers      errors.Msg = "no brokers available"
+	ErrTopicNotFound  errors.Msg = "topic not found"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+// Metadata contains the brokers and topics of a Kafka cluster.
+type Metadata struct {
+	// Brokers contains the addresses of the reachable brokers.
+	Brokers []string
+	// Topics contains the names of the known topics.
+	Topics []string
+}
+
+// MetadataFetcher fetches the [Metadata] of a Kafka cluster. When topics is
+// not empty, the [Metadata] should at least contain the topics of this list
+// which exist.
+type MetadataFetcher interface {
+	FetchMetadata(ctx context.Context, topics []string) (*Metadata, error)
+}
+
+// MetadataFetcherFunc fetches the [Metadata] of a Kafka cluster.
+type MetadataFetcherFunc func(ctx context.Context, topics []string) (*Metadata, error)
+
+func (fn MetadataFetcherFunc) FetchMetadata(ctx context.Context, topics []string) (*Metadata, error) {
+	return fn(ctx, topics)
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a Kafka cluster.
+type Checker struct {
+	fetcher MetadataFetcher
+	topics  []string
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithTopics verifies that all topics exist in the cluster.
+func WithTopics(topics ...string) Option {
+	return func(c *Checker) { c.topics = append(c.topics, topics...) }
+}
+
+const panicNilFetcher = "kafkacheck.New: MetadataFetcher should not be nil"
+
+// New creates a [Checker] which checks the health of a Kafka cluster using the
+// [Metadata] fetched by f.
+func New(f MetadataFetcher, opts ...Option) *Checker {
+	if f == nil {
+		panic(panicNilFetcher)
+	}
+
+	c := Checker{
+		fetcher: f,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the Kafka cluster, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check fetches the cluster's [Metadata] and returns
+// [healthcheck.StatusUnhealthy] and an error when this fails, no brokers are
+// reachable or any of the topics set with [WithTopics] does not exist.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	md, err := c.fetcher.FetchMetadata(ctx, c.topics)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrMetadataFailed)
+	}
+	if md == nil || len(md.Brokers) == 0 {
+		return healthcheck.StatusUnhealthy, errors.New(ErrNoBrokers)
+	}
+	if missing := missingTopics(c.topics, md.Topics); len(missing) != 0 {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrTopicNotFound, "missing %s", strings.Join(missing, ", "))
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func missingTopics(want, have []string) []string {
+	if len(want) == 0 {
+		return nil
+	}
+
+	exists := make(map[string]struct{}, len(have))
+	for _, topic := range have {
+		exists[topic] = struct{}{}
+	}
+
+	var res []string
+	for _, topic := range want {
+		if _, ok := exists[topic]; !ok {
+			res = append(res, topic)
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kafkacheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilFetcher, func() {
+		_ = New(nil)
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	metadata := func(md *Metadata, err error) MetadataFetcher {
+		return MetadataFetcherFunc(func(context.Context, []string) (*Metadata, error) {
+			return md, err
+		})
+	}
+
+	tests := map[string]struct {
+		fetcher    MetadataFetcher
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"healthy": {
+			fetcher:    metadata(&Metadata{Brokers: []string{"kafka-0:9092", "kafka-1:9092"}}, nil),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"fetch failure": {
+			fetcher:    metadata(nil, errors.New("dial tcp: connection refused")),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrMetadataFailed,
+		},
+		"no brokers": {
+			fetcher:    metadata(&Metadata{}, nil),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNoBrokers,
+		},
+		"topics exist": {
+			fetcher:    metadata(&Metadata{Brokers: []string{"kafka-0:9092"}, Topics: []string{"orders", "events"}}, nil),
+			opts:       []Option{WithTopics("orders", "events")},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"topic missing": {
+			fetcher:    metadata(&Metadata{Brokers: []string{"kafka-0:9092"}, Topics: []string{"orders"}}, nil),
+			opts:       []Option{WithTopics("orders", "events")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrTopicNotFound,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.fetcher, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
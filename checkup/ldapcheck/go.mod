@@ -0,0 +1,21 @@
+module github.com/go-pogo/healthcheck/checkup/ldapcheck
+
+go 1.25.0
+
+require (
+	github.com/go-asn1-ber/asn1-ber v1.5.8
+	github.com/go-ldap/ldap/v3 v3.4.14
+	github.com/go-pogo/errors v0.11.2
+	github.com/go-pogo/healthcheck v0.0.0-20261017203950-54cc65100655
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/Azure/go-ntlmssp v0.1.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.54.0 // indirect
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,141 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ldapcheck provides a [healthcheck.HealthChecker] which checks the
+// availability of an LDAP server by performing an anonymous or
+// service-account bind.
+package ldapcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrDialFailed     errors.Msg = "dial failed"
+	ErrStartTLSFailed errors.Msg = "starttls failed"
+	ErrBindFailed     errors.Msg = "bind failed"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the availability of an LDAP server.
+type Checker struct {
+	url       string
+	username  string
+	password  string
+	tlsConfig *tls.Config
+	startTLS  bool
+	timeout   time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithBind performs a bind using the credentials of a service account instead
+// of an anonymous bind.
+func WithBind(username, password string) Option {
+	return func(c *Checker) {
+		c.username, c.password = username, password
+	}
+}
+
+// WithTLSConfig sets the [tls.Config] used when connecting to an ldaps://
+// url, or when upgrading the connection using [WithStartTLS].
+func WithTLSConfig(conf *tls.Config) Option {
+	return func(c *Checker) { c.tlsConfig = conf }
+}
+
+// WithStartTLS upgrades the connection using STARTTLS before binding.
+func WithStartTLS() Option {
+	return func(c *Checker) { c.startTLS = true }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the LDAP server at url, e.g.
+// "ldap://localhost:389" or "ldaps://localhost:636".
+func New(url string, opts ...Option) *Checker {
+	c := Checker{
+		url:     url,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the availability of the server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check connects to the server, optionally upgrades the connection using
+// STARTTLS, and performs a bind. It returns [healthcheck.StatusUnhealthy] and
+// an error when any of these steps fail.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	// the ldap package does not support contexts, derive its timeouts from
+	// the context's deadline instead
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		if timeout = time.Until(deadline); timeout <= 0 {
+			return healthcheck.StatusUnhealthy, errors.Wrap(context.DeadlineExceeded, ErrDialFailed)
+		}
+	}
+
+	conn, err := ldap.DialURL(c.url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(c.tlsConfig),
+	)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrDialFailed)
+	}
+	defer conn.Close()
+
+	if timeout > 0 {
+		conn.SetTimeout(timeout)
+	}
+	if c.startTLS {
+		conf := c.tlsConfig
+		if conf == nil {
+			conf = new(tls.Config)
+		}
+		if err = conn.StartTLS(conf); err != nil {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrStartTLSFailed)
+		}
+	}
+
+	if c.username == "" && c.password == "" {
+		err = conn.UnauthenticatedBind("")
+	} else {
+		err = conn.Bind(c.username, c.password)
+	}
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrBindFailed)
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ldapcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve runs a fake LDAP server which responds to bind requests with
+// ldap.LDAPResultSuccess when the password matches, or
+// ldap.LDAPResultInvalidCredentials otherwise.
+func serve(t *testing.T, password string) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					packet, err := ber.ReadPacket(conn)
+					if err != nil || len(packet.Children) < 2 {
+						return
+					}
+
+					op := packet.Children[1]
+					if op.Tag != ldap.ApplicationBindRequest {
+						continue
+					}
+
+					code := ldap.LDAPResultSuccess
+					if op.Children[2].Data.String() != password {
+						code = ldap.LDAPResultInvalidCredentials
+					}
+					_, _ = conn.Write(bindResponse(packet.Children[0].Value.(int64), code).Bytes())
+				}
+			}(conn)
+		}
+	}()
+	return "ldap://" + lis.Addr().String()
+}
+
+func bindResponse(id int64, code int) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+
+	resp := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationBindResponse, nil, "Bind Response")
+	resp.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "resultCode"))
+	resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	resp.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "diagnosticMessage"))
+	packet.AppendChild(resp)
+	return packet
+}
+
+func TestChecker_Check(t *testing.T) {
+	url := serve(t, "secret")
+	anonURL := serve(t, "")
+
+	tests := map[string]struct {
+		url        string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"anonymous": {
+			url:        anonURL,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"service account": {
+			url:        url,
+			opts:       []Option{WithBind("cn=health,dc=example,dc=org", "secret")},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"invalid credentials": {
+			url:        url,
+			opts:       []Option{WithBind("cn=health,dc=example,dc=org", "wrong")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrBindFailed,
+		},
+		"refused": {
+			url:        "ldap://127.0.0.1:1",
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrDialFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.url, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package memcachedcheck provides a [healthcheck.HealthChecker] which checks
+// the availability of a memcached server using its text protocol.
+package memcachedcheck
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrDialFailed         errors.Msg = "dial failed"
+	ErrCommandFailed      errors.Msg = "command failed"
+	ErrUnexpectedResponse errors.Msg = "unexpected response"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the availability of a memcached server.
+type Checker struct {
+	addr    string
+	stats   bool
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithStats issues a "stats" command instead of the default "version"
+// command.
+func WithStats() Option {
+	return func(c *Checker) { c.stats = true }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the memcached server at addr, of form
+// "host:port".
+func New(addr string, opts ...Option) *Checker {
+	c := Checker{
+		addr:    addr,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the availability of the server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check connects to the server and issues a "version" or "stats" command. It
+// returns [healthcheck.StatusUnhealthy] and an error when the connection
+// cannot be established, the command fails or the response is invalid.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrDialFailed)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	cmd := "version"
+	if c.stats {
+		cmd = "stats"
+	}
+	if _, err = conn.Write([]byte(cmd + "\r\n")); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrCommandFailed)
+	}
+	if err = readResponse(bufio.NewReader(conn), c.stats); err != nil {
+		return healthcheck.StatusUnhealthy, err
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+// readResponse reads the response of a "version" command, which is a single
+// "VERSION <version>" line, or of a "stats" command, which is a list of
+// "STAT <name> <value>" lines terminated by an "END" line.
+func readResponse(r *bufio.Reader, stats bool) error {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return errors.Wrap(err, ErrCommandFailed)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case !stats && strings.HasPrefix(line, "VERSION "):
+			return nil
+		case stats && line == "END":
+			return nil
+		case stats && strings.HasPrefix(line, "STAT "):
+			continue
+		default:
+			return errors.Wrapf(ErrUnexpectedResponse, "response %q", line)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package memcachedcheck
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve runs a fake memcached server which responds to each command with the
+// response from responses.
+func serve(t *testing.T, responses map[string]string) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				line, err := bufio.NewReader(conn).ReadString('\n')
+				if err != nil {
+					return
+				}
+				resp, ok := responses[strings.TrimSpace(line)]
+				if !ok {
+					resp = "ERROR\r\n"
+				}
+				_, _ = conn.Write([]byte(resp))
+			}(conn)
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func TestChecker_Check(t *testing.T) {
+	addr := serve(t, map[string]string{
+		"version": "VERSION 1.6.21\r\n",
+		"stats":   "STAT pid 1\r\nSTAT uptime 42\r\nEND\r\n",
+	})
+	brokenAddr := serve(t, map[string]string{
+		"stats": "STAT pid 1\r\nSERVER_ERROR out of memory\r\n",
+	})
+
+	tests := map[string]struct {
+		addr       string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"version": {
+			addr:       addr,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"stats": {
+			addr:       addr,
+			opts:       []Option{WithStats()},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"unexpected version response": {
+			addr:       brokenAddr,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedResponse,
+		},
+		"unexpected stats response": {
+			addr:       brokenAddr,
+			opts:       []Option{WithStats()},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedResponse,
+		},
+		"refused": {
+			addr:       "127.0.0.1:1",
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrDialFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.addr, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package memcheck provides a [healthcheck.HealthChecker] which checks the
+// memory usage of the current process. It helps to catch memory leaks before
+// the process is killed for running out of memory.
+package memcheck
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrUnsupported       errors.Msg = "not supported on this platform"
+	ErrReadFailed        errors.Msg = "read memory usage failed"
+	ErrHeapExceeded      errors.Msg = "heap size exceeds threshold"
+	ErrRSSExceeded       errors.Msg = "resident set size exceeds threshold"
+	ErrCgroupLimitNearby errors.Msg = "memory usage is near cgroup limit"
+)
+
+// threshold contains a warning and critical value. A value of zero disables
+// the threshold.
+type threshold struct{ warn, crit float64 }
+
+func (t threshold) enabled() bool { return t.warn > 0 || t.crit > 0 }
+
+func (t threshold) status(val float64) healthcheck.Status {
+	switch {
+	case t.crit > 0 && val >= t.crit:
+		return healthcheck.StatusUnhealthy
+	case t.warn > 0 && val >= t.warn:
+		return healthcheck.StatusUnknown
+	default:
+		return healthcheck.StatusHealthy
+	}
+}
+
+// usage contains the memory usage of the current process.
+type usage struct {
+	heap, rss                uint64
+	cgroupUsage, cgroupLimit uint64
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the memory usage of the current process. Without any
+// thresholds set, it always reports [healthcheck.StatusHealthy].
+type Checker struct {
+	heap   threshold
+	rss    threshold
+	cgroup threshold
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithHeap sets the number of bytes of allocated heap objects, as reported by
+// [runtime.MemStats.HeapAlloc], from which the [Checker] reports
+// [healthcheck.StatusUnknown] (warn) and [healthcheck.StatusUnhealthy]
+// (crit). A value of zero disables the threshold.
+func WithHeap(warn, crit uint64) Option {
+	return func(c *Checker) { c.heap = threshold{float64(warn), float64(crit)} }
+}
+
+// WithRSS sets the resident set size of the process in bytes from which the
+// [Checker] reports [healthcheck.StatusUnknown] (warn) and
+// [healthcheck.StatusUnhealthy] (crit). A value of zero disables the
+// threshold. The resident set size is only available on Linux.
+func WithRSS(warn, crit uint64) Option {
+	return func(c *Checker) { c.rss = threshold{float64(warn), float64(crit)} }
+}
+
+// WithCgroupLimit sets the percentages of the memory limit of the process'
+// cgroup from which the [Checker] reports [healthcheck.StatusUnknown] (warn)
+// and [healthcheck.StatusUnhealthy] (crit). A value of zero disables the
+// threshold. Both cgroup v1 and v2 are supported, which are only available
+// on Linux. The threshold is ignored when the cgroup has no memory limit.
+func WithCgroupLimit(warn, crit float64) Option {
+	return func(c *Checker) { c.cgroup = threshold{warn, crit} }
+}
+
+// New creates a [Checker] which checks the memory usage of the current
+// process against the thresholds set using the provided [Option](s).
+func New(opts ...Option) *Checker {
+	var c Checker
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the memory usage, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an error when the memory
+// usage exceeds any of the critical thresholds, or [healthcheck.StatusUnknown]
+// and an error when it exceeds any of the warning thresholds.
+func (c *Checker) Check(context.Context) (healthcheck.Status, error) {
+	var u usage
+	if c.heap.enabled() {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		u.heap = ms.HeapAlloc
+	}
+	if c.rss.enabled() {
+		var err error
+		if u.rss, err = readRSS(); err != nil {
+			return healthcheck.StatusUnknown, errors.Wrap(err, ErrReadFailed)
+		}
+	}
+	if c.cgroup.enabled() {
+		var err error
+		if u.cgroupUsage, u.cgroupLimit, err = readCgroup(); err != nil {
+			return healthcheck.StatusUnknown, errors.Wrap(err, ErrReadFailed)
+		}
+	}
+	return c.status(u)
+}
+
+func (c *Checker) status(u usage) (healthcheck.Status, error) {
+	var cgroupPercent float64
+	if u.cgroupLimit > 0 {
+		cgroupPercent = float64(u.cgroupUsage) / float64(u.cgroupLimit) * 100
+	}
+
+	res, resErr := healthcheck.StatusHealthy, error(nil)
+	for _, r := range []struct {
+		stat healthcheck.Status
+		err  func() error
+	}{
+		{c.heap.status(float64(u.heap)), func() error {
+			return errors.Wrapf(ErrHeapExceeded, "heap size is %d bytes", u.heap)
+		}},
+		{c.rss.status(float64(u.rss)), func() error {
+			return errors.Wrapf(ErrRSSExceeded, "resident set size is %d bytes", u.rss)
+		}},
+		{c.cgroup.status(cgroupPercent), func() error {
+			return errors.Wrapf(ErrCgroupLimitNearby, "memory usage is %.1f%% of %d bytes", cgroupPercent, u.cgroupLimit)
+		}},
+	} {
+		if r.stat == healthcheck.StatusUnhealthy {
+			return r.stat, r.err()
+		}
+		if r.stat == healthcheck.StatusUnknown && res == healthcheck.StatusHealthy {
+			res, resErr = r.stat, r.err()
+		}
+	}
+	return res, resErr
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package memcheck
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Run("no thresholds", func(t *testing.T) {
+		stat, err := New().Check(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err)
+	})
+	t.Run("heap", func(t *testing.T) {
+		stat, err := New(WithHeap(0, 1)).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrHeapExceeded)
+	})
+	t.Run("rss", func(t *testing.T) {
+		stat, err := New(WithRSS(1, 0)).Check(context.Background())
+		if runtime.GOOS != "linux" {
+			assert.ErrorIs(t, err, ErrUnsupported)
+			return
+		}
+		assert.Equal(t, healthcheck.StatusUnknown, stat)
+		assert.ErrorIs(t, err, ErrRSSExceeded)
+	})
+}
+
+func TestChecker_status(t *testing.T) {
+	const mb = 1 << 20
+
+	tests := map[string]struct {
+		usage      usage
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"below thresholds": {
+			usage:      usage{heap: 10 * mb, rss: 20 * mb, cgroupUsage: 20 * mb, cgroupLimit: 100 * mb},
+			opts:       []Option{WithHeap(50*mb, 100*mb), WithRSS(50*mb, 100*mb), WithCgroupLimit(80, 90)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"heap warning": {
+			usage:      usage{heap: 60 * mb},
+			opts:       []Option{WithHeap(50*mb, 100*mb)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrHeapExceeded,
+		},
+		"rss critical": {
+			usage:      usage{heap: 60 * mb, rss: 120 * mb},
+			opts:       []Option{WithHeap(50*mb, 100*mb), WithRSS(50*mb, 100*mb)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrRSSExceeded,
+		},
+		"cgroup critical": {
+			usage:      usage{cgroupUsage: 95 * mb, cgroupLimit: 100 * mb},
+			opts:       []Option{WithCgroupLimit(80, 90)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrCgroupLimitNearby,
+		},
+		"cgroup unlimited": {
+			usage:      usage{cgroupUsage: 95 * mb},
+			opts:       []Option{WithCgroupLimit(80, 90)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.opts...).status(tc.usage)
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadCgroup(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroups are only available on linux")
+	}
+
+	usage, _, err := readCgroup()
+	if err != nil {
+		t.Skip("cgroup memory controller not available:", err)
+	}
+	assert.NotZero(t, usage)
+}
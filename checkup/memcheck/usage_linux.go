@@ -0,0 +1,73 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package memcheck
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+
+	"github.com/go-pogo/errors"
+)
+
+// readRSS reads the resident set size of the current process from
+// /proc/self/statm.
+func readRSS() (uint64, error) {
+	data, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	fields := bytes.Fields(data)
+	if len(fields) < 2 {
+		return 0, errors.Newf("unexpected format of /proc/self/statm: %q", data)
+	}
+
+	pages, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return pages * uint64(os.Getpagesize()), nil
+}
+
+// readCgroup reads the memory usage and limit of the cgroup of the current
+// process. It returns a zero limit when the cgroup has no memory limit.
+func readCgroup() (usage, limit uint64, err error) {
+	// cgroup v2
+	usage, err = readUint("/sys/fs/cgroup/memory.current")
+	if err == nil {
+		limit, err = readUint("/sys/fs/cgroup/memory.max")
+		return usage, limit, err
+	}
+
+	// cgroup v1
+	usage, err = readUint("/sys/fs/cgroup/memory/memory.usage_in_bytes")
+	if err != nil {
+		return 0, 0, err
+	}
+	limit, err = readUint("/sys/fs/cgroup/memory/memory.limit_in_bytes")
+	// an unlimited cgroup v1 reports a very large, page aligned, number
+	if limit >= 1<<62 {
+		limit = 0
+	}
+	return usage, limit, err
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if string(data) == "max" {
+		return 0, nil
+	}
+
+	val, err := strconv.ParseUint(string(data), 10, 64)
+	return val, errors.WithStack(err)
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package memcheck
+
+import "github.com/go-pogo/errors"
+
+func readRSS() (uint64, error) { return 0, errors.New(ErrUnsupported) }
+
+func readCgroup() (uint64, uint64, error) { return 0, 0, errors.New(ErrUnsupported) }
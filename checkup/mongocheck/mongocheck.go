@@ -0,0 +1,108 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mongocheck provides a [healthcheck.HealthChecker] which checks the
+// health of a MongoDB deployment.
+package mongocheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+const (
+	ErrPingFailed  errors.Msg = "ping failed"
+	ErrHelloFailed errors.Msg = "hello failed"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a MongoDB deployment.
+type Checker struct {
+	client   *mongo.Client
+	readPref *readpref.ReadPref
+	timeout  time.Duration
+	hello    bool
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithReadPreference sets the [readpref.ReadPref] used to select the server
+// to ping. By default, the client's read preference is used.
+func WithReadPreference(rp *readpref.ReadPref) Option {
+	return func(c *Checker) { c.readPref = rp }
+}
+
+// WithHello runs the "hello" command instead of "ping". Unlike "ping", the
+// "hello" command does not require authentication.
+func WithHello() Option {
+	return func(c *Checker) { c.hello = true }
+}
+
+const panicNilClient = "mongocheck.New: mongo.Client should not be nil"
+
+// New creates a [Checker] which checks the health of the MongoDB deployment
+// client is connected to.
+func New(client *mongo.Client, opts ...Option) *Checker {
+	if client == nil {
+		panic(panicNilClient)
+	}
+
+	c := Checker{
+		client:  client,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the MongoDB deployment, see
+// [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check pings the MongoDB deployment and returns [healthcheck.StatusUnhealthy]
+// and an error when this fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	if c.hello {
+		err := c.client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Err()
+		if err != nil {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrHelloFailed)
+		}
+		return healthcheck.StatusHealthy, nil
+	}
+
+	if err := c.client.Ping(ctx, c.readPref); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrPingFailed)
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mongocheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilClient, func() {
+		_ = New(nil)
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	client, err := mongo.Connect(options.Client().
+		ApplyURI("mongodb://127.0.0.1:1").
+		SetServerSelectionTimeout(50 * time.Millisecond),
+	)
+	assert.NoError(t, err)
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	tests := map[string]struct {
+		opts    []Option
+		wantErr error
+	}{
+		"ping":  {wantErr: ErrPingFailed},
+		"hello": {opts: []Option{WithHello()}, wantErr: ErrHelloFailed},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(client, tc.opts...).Check(context.Background())
+			assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
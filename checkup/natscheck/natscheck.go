@@ -0,0 +1,129 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package natscheck provides a [healthcheck.HealthChecker] which checks the
+// health of a connection to a NATS server. A *nats.Conn from
+// github.com/nats-io/nats.go implements both [Conn] and [Flusher].
+package natscheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrClosed       errors.Msg = "connection is closed"
+	ErrReconnecting errors.Msg = "connection is reconnecting"
+	ErrNotConnected errors.Msg = "connection is not connected"
+	ErrFlushFailed  errors.Msg = "flush failed"
+)
+
+// DefaultTimeout is the maximum duration of a flush when neither a timeout
+// is set using [WithTimeout], nor the context has a deadline.
+const DefaultTimeout = 2 * time.Second
+
+// Conn is a connection to a NATS server.
+type Conn interface {
+	IsConnected() bool
+	IsReconnecting() bool
+	IsClosed() bool
+}
+
+// Flusher performs a round trip to the NATS server.
+type Flusher interface {
+	FlushWithContext(ctx context.Context) error
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a connection to a NATS server.
+type Checker struct {
+	conn    Conn
+	flusher Flusher
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithTimeout sets the maximum duration of a single health check. When zero
+// and the context has no deadline, [DefaultTimeout] is used, as a flush
+// requires a context with a deadline.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+const panicNoFlusher = "natscheck.WithFlush: Conn does not implement Flusher"
+
+// WithFlush performs a round trip to the server by flushing the connection,
+// when it is connected. The [Conn] provided to [New] must implement [Flusher].
+func WithFlush() Option {
+	return func(c *Checker) {
+		f, ok := c.conn.(Flusher)
+		if !ok {
+			panic(panicNoFlusher)
+		}
+		c.flusher = f
+	}
+}
+
+const panicNilConn = "natscheck.New: Conn should not be nil"
+
+// New creates a [Checker] which checks the health of conn.
+func New(conn Conn, opts ...Option) *Checker {
+	if conn == nil {
+		panic(panicNilConn)
+	}
+
+	c := Checker{conn: conn}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the connection, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusHealthy] when the connection is connected.
+// While reconnecting, [healthcheck.StatusUnknown] is returned together with
+// an [ErrReconnecting] error. A closed or otherwise disconnected connection
+// is [healthcheck.StatusUnhealthy].
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	switch {
+	case c.conn.IsClosed():
+		return healthcheck.StatusUnhealthy, errors.New(ErrClosed)
+	case c.conn.IsReconnecting():
+		return healthcheck.StatusUnknown, errors.New(ErrReconnecting)
+	case !c.conn.IsConnected():
+		return healthcheck.StatusUnhealthy, errors.New(ErrNotConnected)
+	case c.flusher == nil:
+		return healthcheck.StatusHealthy, nil
+	}
+
+	timeout := c.timeout
+	if timeout <= 0 {
+		if _, ok := ctx.Deadline(); !ok {
+			timeout = DefaultTimeout
+		}
+	}
+	if timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, timeout)
+		defer cancelFn()
+	}
+
+	if err := c.flusher.FlushWithContext(ctx); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrFlushFailed)
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package natscheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilConn, func() {
+		_ = New(nil)
+	})
+	assert.PanicsWithValue(t, panicNoFlusher, func() {
+		_ = New(new(statusConn), WithFlush())
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		conn       Conn
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"connected": {
+			conn:       &statusConn{connected: true},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"reconnecting": {
+			conn:       &statusConn{reconnecting: true},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrReconnecting,
+		},
+		"closed": {
+			conn:       &statusConn{closed: true},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrClosed,
+		},
+		"not connected": {
+			conn:       new(statusConn),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNotConnected,
+		},
+		"flush without deadline": {
+			conn:       &flushConn{statusConn: statusConn{connected: true}},
+			opts:       []Option{WithFlush()},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"flush with timeout": {
+			conn:       &flushConn{statusConn: statusConn{connected: true}},
+			opts:       []Option{WithFlush(), WithTimeout(time.Second)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"flush failure": {
+			conn: &flushConn{
+				statusConn: statusConn{connected: true},
+				err:        errors.New("nats: timeout"),
+			},
+			opts:       []Option{WithFlush()},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrFlushFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.conn, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+type statusConn struct {
+	connected, reconnecting, closed bool
+}
+
+func (c *statusConn) IsConnected() bool    { return c.connected }
+func (c *statusConn) IsReconnecting() bool { return c.reconnecting }
+func (c *statusConn) IsClosed() bool       { return c.closed }
+
+type flushConn struct {
+	statusConn
+	err error
+}
+
+// FlushWithContext mimics *nats.Conn, which requires a context with a
+// deadline.
+func (c *flushConn) FlushWithContext(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("nats: context requires a deadline")
+	}
+	return c.err
+}
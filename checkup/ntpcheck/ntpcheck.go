@@ -0,0 +1,197 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ntpcheck provides a [healthcheck.HealthChecker] which checks the
+// offset of the local clock against an NTP server. Clock drift silently
+// breaks TLS, JWTs and distributed coordination.
+package ntpcheck
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrQueryFailed     errors.Msg = "ntp query failed"
+	ErrInvalidResponse errors.Msg = "invalid ntp response"
+	ErrOffsetExceeded  errors.Msg = "clock offset exceeds threshold"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a [Checker].
+	DefaultTimeout = 5 * time.Second
+	// DefaultWarnOffset is the default offset above which the clock is
+	// considered degraded.
+	DefaultWarnOffset = 100 * time.Millisecond
+	// DefaultCritOffset is the default offset above which the clock is
+	// considered unhealthy.
+	DefaultCritOffset = time.Second
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the offset of the local clock against an NTP server.
+type Checker struct {
+	addr    string
+	timeout time.Duration
+	warn    time.Duration
+	crit    time.Duration
+	now     func() time.Time
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithThresholds sets the offsets above which the clock is considered
+// degraded (warn) or unhealthy (crit). A threshold of zero or less disables
+// it.
+func WithThresholds(warn, crit time.Duration) Option {
+	return func(c *Checker) {
+		c.warn, c.crit = warn, crit
+	}
+}
+
+// WithTimeout sets the timeout of the query. A timeout of zero or less means
+// no timeout other than the deadline of the context passed to
+// [Checker.CheckHealth].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which queries the NTP server at addr, of form
+// "host" or "host:port". The default port is 123. The default thresholds are
+// [DefaultWarnOffset] and [DefaultCritOffset], the default timeout is
+// [DefaultTimeout].
+func New(addr string, opts ...Option) *Checker {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "123")
+	}
+
+	c := Checker{
+		addr:    addr,
+		timeout: DefaultTimeout,
+		warn:    DefaultWarnOffset,
+		crit:    DefaultCritOffset,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the clock offset, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check queries the NTP server and compares the clock offset against the
+// thresholds. It returns [healthcheck.StatusUnknown] and an error when the
+// server cannot be queried or the warn threshold is exceeded, and
+// [healthcheck.StatusUnhealthy] when the crit threshold is exceeded.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	offset, err := c.Offset(ctx)
+	if err != nil {
+		return healthcheck.StatusUnknown, err
+	}
+	return c.status(offset)
+}
+
+func (c *Checker) status(offset time.Duration) (healthcheck.Status, error) {
+	if offset < 0 {
+		offset = -offset
+	}
+	if c.crit > 0 && offset > c.crit {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrOffsetExceeded, "offset %s > %s", offset, c.crit)
+	}
+	if c.warn > 0 && offset > c.warn {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrOffsetExceeded, "offset %s > %s", offset, c.warn)
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+const packetSize = 48
+
+// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and
+// the unix epoch (1970).
+const ntpEpochOffset = 2208988800
+
+// Offset queries the NTP server using SNTP and returns the offset of the
+// local clock. A positive offset means the local clock is behind.
+func (c *Checker) Offset(ctx context.Context) (time.Duration, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", c.addr)
+	if err != nil {
+		return 0, errors.Wrap(err, ErrQueryFailed)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	req := make([]byte, packetSize)
+	req[0] = 0x23 // leap indicator 0, version 4, mode 3 (client)
+
+	t1 := c.now()
+	putTime(req[40:], t1) // transmit timestamp
+	if _, err = conn.Write(req); err != nil {
+		return 0, errors.Wrap(err, ErrQueryFailed)
+	}
+
+	resp := make([]byte, packetSize)
+	n, err := conn.Read(resp)
+	t4 := c.now()
+	if err != nil {
+		return 0, errors.Wrap(err, ErrQueryFailed)
+	}
+	return offset(req, resp[:n], t1, t4)
+}
+
+func offset(req, resp []byte, t1, t4 time.Time) (time.Duration, error) {
+	if len(resp) < packetSize {
+		return 0, errors.Wrapf(ErrInvalidResponse, "packet size %d", len(resp))
+	}
+	if mode := resp[0] & 0x7; mode != 4 {
+		return 0, errors.Wrapf(ErrInvalidResponse, "mode %d", mode)
+	}
+	if stratum := resp[1]; stratum == 0 || stratum > 15 {
+		return 0, errors.Wrapf(ErrInvalidResponse, "stratum %d", stratum)
+	}
+	// the originate timestamp should equal the sent transmit timestamp
+	if string(resp[24:32]) != string(req[40:48]) {
+		return 0, errors.Wrap(ErrInvalidResponse, "originate timestamp mismatch")
+	}
+
+	t2 := getTime(resp[32:]) // receive timestamp
+	t3 := getTime(resp[40:]) // transmit timestamp
+	return (t2.Sub(t1) + t3.Sub(t4)) / 2, nil
+}
+
+func putTime(b []byte, t time.Time) {
+	sec := uint64(t.Unix()) + ntpEpochOffset
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	binary.BigEndian.PutUint32(b[0:], uint32(sec))
+	binary.BigEndian.PutUint32(b[4:], uint32(frac))
+}
+
+func getTime(b []byte) time.Time {
+	sec := int64(binary.BigEndian.Uint32(b[0:])) - ntpEpochOffset
+	frac := uint64(binary.BigEndian.Uint32(b[4:]))
+	return time.Unix(sec, int64(frac*uint64(time.Second)>>32))
+}
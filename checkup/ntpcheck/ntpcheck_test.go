@@ -0,0 +1,97 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ntpcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve runs a minimal SNTP server with a clock which is skew ahead of the
+// local clock.
+func serve(t *testing.T, skew time.Duration) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, packetSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < packetSize {
+				continue
+			}
+
+			resp := make([]byte, packetSize)
+			resp[0] = 0x24 // version 4, mode 4 (server)
+			resp[1] = 2    // stratum
+			copy(resp[24:32], buf[40:48])
+			now := time.Now().Add(skew)
+			putTime(resp[32:], now)
+			putTime(resp[40:], now)
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		skew       time.Duration
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"in sync": {
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"degraded": {
+			skew:       500 * time.Millisecond,
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrOffsetExceeded,
+		},
+		"unhealthy": {
+			skew:       -time.Hour,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrOffsetExceeded,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(serve(t, tc.skew)).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("timeout", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		stat, err := New(conn.LocalAddr().String(), WithTimeout(50*time.Millisecond)).
+			Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnknown, stat)
+		assert.ErrorIs(t, err, ErrQueryFailed)
+	})
+}
+
+func TestTime(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 30, 15, 250_000_000, time.UTC)
+	b := make([]byte, 8)
+	putTime(b, want)
+	assert.WithinDuration(t, want, getTime(b), time.Microsecond)
+}
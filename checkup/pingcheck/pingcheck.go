@@ -0,0 +1,203 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pingcheck provides a [healthcheck.HealthChecker] which checks if a
+// host is reachable using an ICMP echo request (ping). Raw ICMP sockets
+// require elevated privileges, when these are not available the [Checker]
+// falls back to unprivileged "udp" ICMP sockets, which on Linux are limited
+// to the groups in the net.ipv4.ping_group_range sysctl.
+package pingcheck
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	ErrResolveFailed   errors.Msg = "resolve failed"
+	ErrListenFailed    errors.Msg = "listen failed"
+	ErrPingFailed      errors.Msg = "ping failed"
+	ErrMaxRTTExceeded  errors.Msg = "max round trip time exceeded"
+	ErrUnexpectedReply errors.Msg = "unexpected reply"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+const (
+	protocolICMP     = 1
+	protocolICMPv6   = 58
+	maxMessageLength = 1500
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks if a host is reachable using ping.
+type Checker struct {
+	host    string
+	timeout time.Duration
+	maxRTT  time.Duration
+	seq     atomic.Uint32
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithMaxRTT reports a host which replies, but with a round trip time longer
+// than max, as [healthcheck.StatusUnknown] together with an
+// [ErrMaxRTTExceeded] error.
+func WithMaxRTT(max time.Duration) Option {
+	return func(c *Checker) { c.maxRTT = max }
+}
+
+// New creates a [Checker] which pings host, which is either a host name or
+// ip address.
+func New(host string, opts ...Option) *Checker {
+	c := Checker{
+		host:    host,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks if the host is reachable, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check sends an ICMP echo request to the host and waits for the reply. It
+// returns [healthcheck.StatusUnhealthy] and an error when no reply is
+// received in time.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	rtt, err := c.ping(ctx)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, err
+	}
+	if c.maxRTT > 0 && rtt > c.maxRTT {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrMaxRTTExceeded, "round trip time %s", rtt)
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func (c *Checker) ping(ctx context.Context) (time.Duration, error) {
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, c.host)
+	if err != nil {
+		return 0, errors.Wrap(err, ErrResolveFailed)
+	}
+	if len(ips) == 0 {
+		return 0, errors.Wrapf(ErrResolveFailed, "no addresses found for %q", c.host)
+	}
+
+	ip := ips[0].IP
+	conn, privileged, err := listen(ip.To4() != nil)
+	if err != nil {
+		return 0, errors.Wrap(err, ErrListenFailed)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return 0, errors.Wrap(err, ErrPingFailed)
+		}
+	}
+
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{
+			ID:   os.Getpid() & 0xffff,
+			Seq:  int(c.seq.Add(1) & 0xffff),
+			Data: []byte("go-pogo/healthcheck"),
+		},
+	}
+	proto := protocolICMP
+	if ip.To4() == nil {
+		msg.Type = ipv6.ICMPTypeEchoRequest
+		proto = protocolICMPv6
+	}
+
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return 0, errors.Wrap(err, ErrPingFailed)
+	}
+
+	var dst net.Addr = &net.UDPAddr{IP: ip, Zone: ips[0].Zone}
+	if privileged {
+		dst = &ips[0]
+	}
+
+	start := time.Now()
+	if _, err = conn.WriteTo(b, dst); err != nil {
+		return 0, errors.Wrap(err, ErrPingFailed)
+	}
+
+	buf := make([]byte, maxMessageLength)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, errors.Wrap(err, ErrPingFailed)
+		}
+
+		reply, err := icmp.ParseMessage(proto, buf[:n])
+		if err != nil {
+			return 0, errors.Wrap(err, ErrUnexpectedReply)
+		}
+		if reply.Type != ipv4.ICMPTypeEchoReply && reply.Type != ipv6.ICMPTypeEchoReply {
+			continue
+		}
+
+		// the kernel rewrites the id of unprivileged echo requests, only the
+		// sequence number can be matched
+		echo, ok := reply.Body.(*icmp.Echo)
+		sent := msg.Body.(*icmp.Echo)
+		if ok && echo.Seq == sent.Seq && (!privileged || echo.ID == sent.ID) {
+			return time.Since(start), nil
+		}
+	}
+}
+
+// listen opens a privileged ICMP socket, or an unprivileged one when this
+// fails.
+func listen(v4 bool) (*icmp.PacketConn, bool, error) {
+	network, address := "ip4:icmp", "0.0.0.0"
+	if !v4 {
+		network, address = "ip6:ipv6-icmp", "::"
+	}
+	if conn, err := icmp.ListenPacket(network, address); err == nil {
+		return conn, true, nil
+	}
+
+	network = "udp4"
+	if !v4 {
+		network = "udp6"
+	}
+	conn, err := icmp.ListenPacket(network, address)
+	return conn, false, err
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pingcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	if conn, _, err := listen(true); err != nil {
+		t.Skip("icmp sockets are not permitted:", err)
+	} else {
+		_ = conn.Close()
+	}
+
+	t.Run("loopback", func(t *testing.T) {
+		stat, err := New("127.0.0.1").Check(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err)
+	})
+	t.Run("max rtt", func(t *testing.T) {
+		stat, err := New("127.0.0.1", WithMaxRTT(time.Nanosecond)).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnknown, stat)
+		assert.ErrorIs(t, err, ErrMaxRTTExceeded)
+	})
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancelFn := context.WithDeadline(context.Background(), time.Now())
+		defer cancelFn()
+
+		stat, err := New("127.0.0.1").Check(ctx)
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redischeck provides a [healthcheck.HealthChecker] which checks the
+// health of a Redis server. It does not depend on a specific Redis client,
+// any client can be used by wrapping it in a [PingerFunc]:
+//
+//	// github.com/redis/go-redis
+//	redischeck.New(redischeck.PingerFunc(func(ctx context.Context) error {
+//		return rdb.Ping(ctx).Err()
+//	}))
+//
+//	// github.com/redis/rueidis
+//	redischeck.New(redischeck.PingerFunc(func(ctx context.Context) error {
+//		return client.Do(ctx, client.B().Ping().Build()).Error()
+//	}))
+package redischeck
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrPingFailed            errors.Msg = "ping failed"
+	ErrInfoFailed            errors.Msg = "info replication failed"
+	ErrMasterLinkDown        errors.Msg = "master link is down"
+	ErrMaxLatencyExceeded    errors.Msg = "max latency exceeded"
+	ErrMaxReplicationLag     errors.Msg = "max replication lag exceeded"
+	ErrInvalidReplicationLag errors.Msg = "invalid replication lag"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+// Pinger sends a PING command to a Redis server.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingerFunc sends a PING command to a Redis server.
+type PingerFunc func(ctx context.Context) error
+
+func (fn PingerFunc) Ping(ctx context.Context) error { return fn(ctx) }
+
+// InfoFunc returns the raw output of the INFO command for section.
+//
+//	// github.com/redis/go-redis
+//	func(ctx context.Context, section string) (string, error) {
+//		return rdb.Info(ctx, section).Result()
+//	}
+type InfoFunc func(ctx context.Context, section string) (string, error)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a Redis server.
+type Checker struct {
+	pinger     Pinger
+	info       InfoFunc
+	timeout    time.Duration
+	maxLatency time.Duration
+	maxLag     time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithMaxLatency reports a server which responds successfully, but takes
+// longer than max to do so, as [healthcheck.StatusUnknown] together with an
+// [ErrMaxLatencyExceeded] error.
+func WithMaxLatency(max time.Duration) Option {
+	return func(c *Checker) { c.maxLatency = max }
+}
+
+// WithMaxReplicationLag uses info to request the replication state of the
+// server. A replica which did not hear from its master for more than max, or
+// a master with a replica lagging more than max behind, is reported as
+// [healthcheck.StatusUnknown] together with an [ErrMaxReplicationLag] error.
+// A replica whose link to its master is down is reported as
+// [healthcheck.StatusUnhealthy].
+func WithMaxReplicationLag(info InfoFunc, max time.Duration) Option {
+	return func(c *Checker) {
+		c.info = info
+		c.maxLag = max
+	}
+}
+
+const panicNilPinger = "redischeck.New: Pinger should not be nil"
+
+// New creates a [Checker] which checks the health of a Redis server using p.
+func New(p Pinger, opts ...Option) *Checker {
+	if p == nil {
+		panic(panicNilPinger)
+	}
+
+	c := Checker{
+		pinger:  p,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the Redis server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check pings the Redis server and returns [healthcheck.StatusUnhealthy] and
+// an error when this fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	start := time.Now()
+	if err := c.pinger.Ping(ctx); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrPingFailed)
+	}
+	if c.maxLatency > 0 && time.Since(start) > c.maxLatency {
+		return healthcheck.StatusUnknown, errors.New(ErrMaxLatencyExceeded)
+	}
+	if c.info == nil {
+		return healthcheck.StatusHealthy, nil
+	}
+
+	info, err := c.info(ctx, "replication")
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrInfoFailed)
+	}
+	return checkReplication(parseInfo(info), c.maxLag)
+}
+
+func checkReplication(info map[string]string, max time.Duration) (healthcheck.Status, error) {
+	if info["role"] == "slave" {
+		if info["master_link_status"] != "up" {
+			return healthcheck.StatusUnhealthy, errors.New(ErrMasterLinkDown)
+		}
+
+		lag, err := strconv.Atoi(info["master_last_io_seconds_ago"])
+		if err != nil {
+			return healthcheck.StatusUnknown, errors.Wrap(err, ErrInvalidReplicationLag)
+		}
+		if lag := time.Duration(lag) * time.Second; lag > max {
+			return healthcheck.StatusUnknown, errors.Wrapf(ErrMaxReplicationLag, "master last seen %s ago", lag)
+		}
+		return healthcheck.StatusHealthy, nil
+	}
+
+	for key, val := range info {
+		if !strings.HasPrefix(key, "slave") || !strings.Contains(val, "lag=") {
+			continue
+		}
+
+		_, lagStr, _ := strings.Cut(val, "lag=")
+		lagStr, _, _ = strings.Cut(lagStr, ",")
+		lag, err := strconv.Atoi(lagStr)
+		if err != nil {
+			return healthcheck.StatusUnknown, errors.Wrap(err, ErrInvalidReplicationLag)
+		}
+		if lag := time.Duration(lag) * time.Second; lag > max {
+			return healthcheck.StatusUnknown, errors.Wrapf(ErrMaxReplicationLag, "replica %s lags %s behind", key, lag)
+		}
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+// parseInfo parses the "key:value" lines of the output of the INFO command.
+func parseInfo(info string) map[string]string {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, val, ok := strings.Cut(line, ":"); ok {
+			res[key] = val
+		}
+	}
+	return res
+}
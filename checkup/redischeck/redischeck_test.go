@@ -0,0 +1,106 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package redischeck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilPinger, func() {
+		_ = New(nil)
+	})
+
+	pong := PingerFunc(func(context.Context) error { return nil })
+	assert.Equal(t, DefaultTimeout, New(pong).timeout)
+	assert.Equal(t, time.Second, New(pong, WithTimeout(time.Second)).timeout)
+}
+
+func TestChecker_Check(t *testing.T) {
+	pong := PingerFunc(func(context.Context) error { return nil })
+	info := func(s string) InfoFunc {
+		return func(context.Context, string) (string, error) { return s, nil }
+	}
+
+	tests := map[string]struct {
+		pinger     Pinger
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"healthy": {
+			pinger:     pong,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"ping failure": {
+			pinger: PingerFunc(func(context.Context) error {
+				return errors.New("connection refused")
+			}),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrPingFailed,
+		},
+		"slow": {
+			pinger: PingerFunc(func(context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			}),
+			opts:       []Option{WithMaxLatency(time.Millisecond)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrMaxLatencyExceeded,
+		},
+		"replica in sync": {
+			pinger: pong,
+			opts: []Option{WithMaxReplicationLag(
+				info("# Replication\r\nrole:slave\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:1\r\n"),
+				5*time.Second,
+			)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"replica lagging": {
+			pinger: pong,
+			opts: []Option{WithMaxReplicationLag(
+				info("role:slave\r\nmaster_link_status:up\r\nmaster_last_io_seconds_ago:10\r\n"),
+				5*time.Second,
+			)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrMaxReplicationLag,
+		},
+		"replica link down": {
+			pinger: pong,
+			opts: []Option{WithMaxReplicationLag(
+				info("role:slave\r\nmaster_link_status:down\r\n"),
+				5*time.Second,
+			)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrMasterLinkDown,
+		},
+		"master with lagging replica": {
+			pinger: pong,
+			opts: []Option{WithMaxReplicationLag(
+				info("role:master\r\nconnected_slaves:1\r\nslave0:ip=10.0.0.2,port=6379,state=online,offset=100,lag=9\r\n"),
+				5*time.Second,
+			)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrMaxReplicationLag,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.pinger, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
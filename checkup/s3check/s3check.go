@@ -0,0 +1,129 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3check provides a [healthcheck.HealthChecker] which checks the
+// reachability of a bucket on an S3-compatible object storage, verifying both
+// connectivity and credentials. It does not depend on a specific SDK, any
+// client (e.g. AWS or MinIO) can be used by implementing [BucketHeader] and
+// optionally [ObjectLister], or wrapping them in a [BucketHeaderFunc] and
+// [ObjectListerFunc].
+package s3check
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrHeadBucketFailed errors.Msg = "head bucket failed"
+	ErrListFailed       errors.Msg = "list objects failed"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+// BucketHeader performs a HEAD bucket request. It should return an error when
+// the bucket does not exist or is not accessible with the used credentials.
+type BucketHeader interface {
+	HeadBucket(ctx context.Context, bucket string) error
+}
+
+// BucketHeaderFunc performs a HEAD bucket request.
+type BucketHeaderFunc func(ctx context.Context, bucket string) error
+
+func (fn BucketHeaderFunc) HeadBucket(ctx context.Context, bucket string) error {
+	return fn(ctx, bucket)
+}
+
+// ObjectLister lists at most limit objects with prefix in bucket.
+type ObjectLister interface {
+	ListObjects(ctx context.Context, bucket, prefix string, limit int) error
+}
+
+// ObjectListerFunc lists at most limit objects with prefix in bucket.
+type ObjectListerFunc func(ctx context.Context, bucket, prefix string, limit int) error
+
+func (fn ObjectListerFunc) ListObjects(ctx context.Context, bucket, prefix string, limit int) error {
+	return fn(ctx, bucket, prefix, limit)
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the reachability of a bucket.
+type Checker struct {
+	header  BucketHeader
+	bucket  string
+	lister  ObjectLister
+	prefix  string
+	timeout time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithList additionally lists a single object with prefix to verify the
+// credentials have read access to the bucket's contents.
+func WithList(l ObjectLister, prefix string) Option {
+	return func(c *Checker) {
+		c.lister = l
+		c.prefix = prefix
+	}
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+const panicNilHeader = "s3check.New: BucketHeader should not be nil"
+
+// New creates a [Checker] which checks the reachability of bucket using h.
+func New(h BucketHeader, bucket string, opts ...Option) *Checker {
+	if h == nil {
+		panic(panicNilHeader)
+	}
+
+	c := Checker{
+		header:  h,
+		bucket:  bucket,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the reachability of the bucket, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check performs a HEAD bucket request and, when set using [WithList], lists
+// a single object. It returns [healthcheck.StatusUnhealthy] and an error when
+// either of these fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	if err := c.header.HeadBucket(ctx, c.bucket); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrHeadBucketFailed)
+	}
+	if c.lister != nil {
+		if err := c.lister.ListObjects(ctx, c.bucket, c.prefix, 1); err != nil {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrListFailed)
+		}
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package s3check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHeader, func() {
+		_ = New(nil, "bucket")
+	})
+}
+
+func TestChecker_Check(t *testing.T) {
+	head := func(err error) BucketHeader {
+		return BucketHeaderFunc(func(_ context.Context, bucket string) error {
+			assert.Equal(t, "bucket", bucket)
+			return err
+		})
+	}
+	list := func(err error) ObjectLister {
+		return ObjectListerFunc(func(_ context.Context, bucket, prefix string, limit int) error {
+			assert.Equal(t, "bucket", bucket)
+			assert.Equal(t, "health/", prefix)
+			assert.Equal(t, 1, limit)
+			return err
+		})
+	}
+
+	tests := map[string]struct {
+		header     BucketHeader
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"healthy": {
+			header:     head(nil),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"head failure": {
+			header:     head(errors.New("403 forbidden")),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrHeadBucketFailed,
+		},
+		"list": {
+			header:     head(nil),
+			opts:       []Option{WithList(list(nil), "health/")},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"list failure": {
+			header:     head(nil),
+			opts:       []Option{WithList(list(errors.New("access denied")), "health/")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrListFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.header, "bucket", tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package smtpcheck provides a [healthcheck.HealthChecker] which checks if a
+// connection can be established with an SMTP server, optionally followed by
+// an EHLO, STARTTLS and/or NOOP command.
+package smtpcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrDialFailed          errors.Msg = "dial failed"
+	ErrHelloFailed         errors.Msg = "hello failed"
+	ErrStartTLSUnsupported errors.Msg = "server does not support STARTTLS"
+	ErrStartTLSFailed      errors.Msg = "starttls failed"
+	ErrNoopFailed          errors.Msg = "noop failed"
+)
+
+// DefaultTimeout is the default timeout of a [Checker].
+const DefaultTimeout = 5 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the availability of an SMTP server.
+type Checker struct {
+	addr      string
+	timeout   time.Duration
+	localName string
+	tlsConfig *tls.Config
+	noop      bool
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithHello sends an EHLO (or HELO) command with localName as the client's
+// hostname.
+func WithHello(localName string) Option {
+	return func(c *Checker) { c.localName = localName }
+}
+
+// WithStartTLS upgrades the connection using STARTTLS and the provided
+// [tls.Config]. A server which does not support STARTTLS is considered
+// unhealthy. When the config is nil, a config with the host of the server's
+// address as ServerName is used.
+func WithStartTLS(conf *tls.Config) Option {
+	return func(c *Checker) {
+		if conf == nil {
+			conf = new(tls.Config)
+		}
+		c.tlsConfig = conf
+	}
+}
+
+// WithNoop sends a NOOP command to the server.
+func WithNoop() Option {
+	return func(c *Checker) { c.noop = true }
+}
+
+// WithTimeout sets the timeout of the complete check. A timeout of zero or
+// less means no timeout other than the deadline of the context passed to
+// [Checker.CheckHealth].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the SMTP server at addr, of form
+// "host:port". The default timeout is [DefaultTimeout].
+func New(addr string, opts ...Option) *Checker {
+	c := Checker{
+		addr:    addr,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the SMTP server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check connects to the SMTP server, performs the configured commands and
+// quits. It returns [healthcheck.StatusUnhealthy] and an error when any of
+// these steps fail.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrDialFailed)
+	}
+	defer conn.Close()
+
+	// net/smtp does not support contexts, use the context's deadline for all
+	// reads and writes on the connection instead
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(c.addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrDialFailed)
+	}
+	defer client.Close()
+
+	if err = c.session(client, host); err != nil {
+		return healthcheck.StatusUnhealthy, err
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func (c *Checker) session(client *smtp.Client, host string) error {
+	if c.localName != "" {
+		if err := client.Hello(c.localName); err != nil {
+			return errors.Wrap(err, ErrHelloFailed)
+		}
+	}
+	if c.tlsConfig != nil {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New(ErrStartTLSUnsupported)
+		}
+
+		conf := c.tlsConfig
+		if conf.ServerName == "" {
+			conf = conf.Clone()
+			conf.ServerName = host
+		}
+		if err := client.StartTLS(conf); err != nil {
+			return errors.Wrap(err, ErrStartTLSFailed)
+		}
+	}
+	if c.noop {
+		if err := client.Noop(); err != nil {
+			return errors.Wrap(err, ErrNoopFailed)
+		}
+	}
+
+	_ = client.Quit()
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smtpcheck
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve runs a minimal SMTP server which does not support STARTTLS and
+// responds to NOOP with noopReply.
+func serve(t *testing.T, noopReply string) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				_, _ = fmt.Fprint(conn, "220 localhost ESMTP\r\n")
+
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.Fields(line)[0]); cmd {
+					case "EHLO":
+						_, _ = fmt.Fprint(conn, "250-localhost\r\n250 8BITMIME\r\n")
+					case "NOOP":
+						_, _ = fmt.Fprint(conn, noopReply+"\r\n")
+					case "QUIT":
+						_, _ = fmt.Fprint(conn, "221 bye\r\n")
+						return
+					default:
+						_, _ = fmt.Fprint(conn, "502 not implemented\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func TestChecker_Check(t *testing.T) {
+	addr := serve(t, "250 OK")
+	failAddr := serve(t, "421 shutting down")
+
+	tests := map[string]struct {
+		addr       string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"connect": {
+			addr:       addr,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"hello and noop": {
+			addr:       addr,
+			opts:       []Option{WithHello("localhost"), WithNoop()},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"starttls unsupported": {
+			addr:       addr,
+			opts:       []Option{WithStartTLS(nil)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrStartTLSUnsupported,
+		},
+		"noop failed": {
+			addr:       failAddr,
+			opts:       []Option{WithNoop()},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNoopFailed,
+		},
+		"refused": {
+			addr:       "127.0.0.1:1",
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrDialFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := New(tc.addr, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqlcheck provides a [healthcheck.HealthChecker] which checks the
+// health of a database using [database/sql].
+package sqlcheck
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrPingFailed         errors.Msg = "ping failed"
+	ErrQueryFailed        errors.Msg = "validation query failed"
+	ErrMaxLatencyExceeded errors.Msg = "max latency exceeded"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 2 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the health of a database by pinging it and optionally
+// executing a validation query.
+type Checker struct {
+	db         *sql.DB
+	query      string
+	timeout    time.Duration
+	maxLatency time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithQuery sets a validation query, eg. "SELECT 1", which is executed after
+// a successful ping. Any returned rows are discarded.
+func WithQuery(query string) Option {
+	return func(c *Checker) { c.query = query }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithMaxLatency reports a database which responds successfully, but takes
+// longer than max to do so, as [healthcheck.StatusUnknown] together with an
+// [ErrMaxLatencyExceeded] error. A slow database is often a first symptom
+// of an overloaded database.
+func WithMaxLatency(max time.Duration) Option {
+	return func(c *Checker) { c.maxLatency = max }
+}
+
+const panicNilDB = "sqlcheck.New: sql.DB should not be nil"
+
+// New creates a [Checker] which checks the health of db.
+func New(db *sql.DB, opts ...Option) *Checker {
+	if db == nil {
+		panic(panicNilDB)
+	}
+
+	c := Checker{
+		db:      db,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the health of the database, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check pings the database and executes the validation query, if any. It
+// returns [healthcheck.StatusUnhealthy] and an error when either fails.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	start := time.Now()
+	if err := c.db.PingContext(ctx); err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrPingFailed)
+	}
+	if c.query != "" {
+		rows, err := c.db.QueryContext(ctx, c.query)
+		if err == nil {
+			err = rows.Close()
+		}
+		if err != nil {
+			return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrQueryFailed)
+		}
+	}
+	if c.maxLatency > 0 && time.Since(start) > c.maxLatency {
+		return healthcheck.StatusUnknown, errors.New(ErrMaxLatencyExceeded)
+	}
+	return healthcheck.StatusHealthy, nil
+}
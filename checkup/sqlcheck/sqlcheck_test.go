@@ -0,0 +1,124 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlcheck
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilDB, func() {
+		_ = New(nil)
+	})
+
+	db := sql.OpenDB(new(fakeConn))
+	defer db.Close()
+
+	assert.Equal(t, DefaultTimeout, New(db).timeout)
+	assert.Equal(t, time.Second, New(db, WithTimeout(time.Second)).timeout)
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		conn       fakeConn
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"healthy": {
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"ping failure": {
+			conn:       fakeConn{pingErr: errors.New("connection lost")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrPingFailed,
+		},
+		"query": {
+			opts:       []Option{WithQuery("SELECT 1")},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"query failure": {
+			conn:       fakeConn{queryErr: errors.New("syntax error")},
+			opts:       []Option{WithQuery("SELECT 1")},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrQueryFailed,
+		},
+		"slow": {
+			conn:       fakeConn{delay: 10 * time.Millisecond},
+			opts:       []Option{WithMaxLatency(time.Millisecond)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrMaxLatencyExceeded,
+		},
+		"timeout": {
+			conn:       fakeConn{delay: time.Second},
+			opts:       []Option{WithTimeout(10 * time.Millisecond)},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    context.DeadlineExceeded,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			conn := tc.conn
+			db := sql.OpenDB(&conn)
+			defer db.Close()
+
+			stat, err := New(db, tc.opts...).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// fakeConn is a minimal [driver.Connector] and [driver.Conn] which supports
+// pinging and querying.
+type fakeConn struct {
+	pingErr  error
+	queryErr error
+	delay    time.Duration
+}
+
+func (c *fakeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *fakeConn) Driver() driver.Driver                        { return nil }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	if c.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.delay):
+		}
+	}
+	return c.pingErr
+}
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if c.queryErr != nil {
+		return nil, c.queryErr
+	}
+	return new(fakeRows), nil
+}
+
+type fakeRows struct{}
+
+func (*fakeRows) Columns() []string         { return nil }
+func (*fakeRows) Close() error              { return nil }
+func (*fakeRows) Next([]driver.Value) error { return io.EOF }
@@ -0,0 +1,84 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tcpcheck provides a [healthcheck.HealthChecker] which checks if a
+// tcp connection can be established. It is useful for dependencies without
+// any http or gRPC health checking endpoint.
+package tcpcheck
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const ErrDialFailed errors.Msg = "dial failed"
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks if a tcp connection can be established with an address.
+type Checker struct {
+	addr    string
+	timeout time.Duration
+	dialer  *net.Dialer
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithDialer sets the [net.Dialer] used to establish connections. When both
+// its Timeout and the timeout provided to [New] are set, the shorter of the
+// two applies.
+func WithDialer(d *net.Dialer) Option {
+	return func(c *Checker) { c.dialer = d }
+}
+
+// New creates a [Checker] which checks if a tcp connection can be established
+// with addr, of form "host:port", within timeout. A timeout of zero means no
+// timeout other than the deadline of the context passed to
+// [Checker.CheckHealth].
+func New(addr string, timeout time.Duration, opts ...Option) *Checker {
+	c := Checker{
+		addr:    addr,
+		timeout: timeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	if c.dialer == nil {
+		c.dialer = new(net.Dialer)
+	}
+	return &c
+}
+
+// CheckHealth checks if a tcp connection can be established, see
+// [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check establishes a tcp connection, which is closed immediately. It returns
+// [healthcheck.StatusUnhealthy] and an error when the connection cannot be
+// established.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrDialFailed)
+	}
+
+	_ = conn.Close()
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tcpcheck
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	t.Run("listening", func(t *testing.T) {
+		stat, err := New(lis.Addr().String(), time.Second).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err)
+	})
+	t.Run("refused", func(t *testing.T) {
+		stat, err := New("127.0.0.1:1", time.Second).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrDialFailed)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package checkup provides building blocks for creating
+// [healthcheck.HealthChecker](s). Its sub packages contain
+// [healthcheck.HealthChecker](s) for specific dependencies.
+package checkup
+
+import (
+	"context"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrValueFailed   errors.Msg = "failed to get value"
+	ErrWarnThreshold errors.Msg = "value exceeds warn threshold"
+	ErrCritThreshold errors.Msg = "value exceeds crit threshold"
+)
+
+// ValueFunc returns a numeric value, like a queue depth, replication lag or
+// connection pool saturation.
+type ValueFunc func(ctx context.Context) (float64, error)
+
+var _ healthcheck.HealthChecker = (*ThresholdChecker)(nil)
+
+// ThresholdChecker checks a numeric value against a warn and crit threshold.
+type ThresholdChecker struct {
+	fn   ValueFunc
+	warn float64
+	crit float64
+}
+
+const panicNilValueFunc = "checkup.Threshold: ValueFunc should not be nil"
+
+// Threshold creates a [ThresholdChecker] which checks the value returned by
+// fn against the warn and crit thresholds. Values are considered worse when
+// they are higher, unless warn is greater than crit, in which case lower
+// values are considered worse, e.g. the number of idle connections in a pool.
+func Threshold(fn ValueFunc, warn, crit float64) *ThresholdChecker {
+	if fn == nil {
+		panic(panicNilValueFunc)
+	}
+	return &ThresholdChecker{
+		fn:   fn,
+		warn: warn,
+		crit: crit,
+	}
+}
+
+// CheckHealth checks the value, see [ThresholdChecker.Check].
+func (c *ThresholdChecker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check gets the value and returns [healthcheck.StatusUnhealthy] and an error
+// when it exceeds the crit threshold, or [healthcheck.StatusUnknown] and an
+// error when it exceeds the warn threshold or cannot be retrieved.
+func (c *ThresholdChecker) Check(ctx context.Context) (healthcheck.Status, error) {
+	val, err := c.fn(ctx)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrValueFailed)
+	}
+	return c.status(val)
+}
+
+func (c *ThresholdChecker) status(val float64) (healthcheck.Status, error) {
+	if c.exceeds(val, c.crit) {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrCritThreshold, "value %g, threshold %g", val, c.crit)
+	}
+	if c.exceeds(val, c.warn) {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrWarnThreshold, "value %g, threshold %g", val, c.warn)
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func (c *ThresholdChecker) exceeds(val, threshold float64) bool {
+	if c.warn > c.crit {
+		return val < threshold
+	}
+	return val > threshold
+}
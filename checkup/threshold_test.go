@@ -0,0 +1,81 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checkup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreshold(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilValueFunc, func() {
+		_ = Threshold(nil, 1, 2)
+	})
+}
+
+func TestThresholdChecker_Check(t *testing.T) {
+	value := func(v float64, err error) ValueFunc {
+		return func(context.Context) (float64, error) { return v, err }
+	}
+
+	tests := map[string]struct {
+		checker    *ThresholdChecker
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"below warn": {
+			checker:    Threshold(value(10, nil), 100, 1000),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"equals warn": {
+			checker:    Threshold(value(100, nil), 100, 1000),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"exceeds warn": {
+			checker:    Threshold(value(500, nil), 100, 1000),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrWarnThreshold,
+		},
+		"exceeds crit": {
+			checker:    Threshold(value(5000, nil), 100, 1000),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrCritThreshold,
+		},
+		"lower is worse": {
+			checker:    Threshold(value(10, nil), 5, 1),
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"lower is worse exceeds warn": {
+			checker:    Threshold(value(3, nil), 5, 1),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrWarnThreshold,
+		},
+		"lower is worse exceeds crit": {
+			checker:    Threshold(value(0, nil), 5, 1),
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrCritThreshold,
+		},
+		"value failure": {
+			checker:    Threshold(value(0, errors.New("queue unavailable")), 100, 1000),
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrValueFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stat, err := tc.checker.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
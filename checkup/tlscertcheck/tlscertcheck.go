@@ -0,0 +1,200 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tlscertcheck provides a [healthcheck.HealthChecker] which checks the
+// expiry of tls certificates, either presented by a tls endpoint or loaded
+// from a file.
+package tlscertcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrDialFailed     errors.Msg = "dial failed"
+	ErrLoadFailed     errors.Msg = "load certificates failed"
+	ErrNoCertificates errors.Msg = "no certificates found"
+	ErrExpiresSoon    errors.Msg = "certificate expires soon"
+	ErrExpired        errors.Msg = "certificate expired"
+)
+
+// DefaultWindow is the default duration before expiry in which a certificate
+// is considered to expire soon.
+const DefaultWindow = 14 * 24 * time.Hour
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the expiry of tls certificates.
+type Checker struct {
+	load      func(ctx context.Context) ([]*x509.Certificate, error)
+	tlsConfig *tls.Config
+	window    time.Duration
+	timeout   time.Duration
+}
+
+// Option is an option for a [Checker].
+type Option func(c *Checker)
+
+// WithWindow sets the duration before expiry in which a certificate is
+// considered to expire soon. The default is [DefaultWindow].
+func WithWindow(window time.Duration) Option {
+	return func(c *Checker) { c.window = window }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// WithTLSConfig sets the [tls.Config] used to connect to the endpoint of a
+// [Checker] created with [Dial]. Certificates are always retrieved without
+// verification, so an expired certificate can be reported as such.
+func WithTLSConfig(conf *tls.Config) Option {
+	return func(c *Checker) { c.tlsConfig = conf }
+}
+
+// Dial creates a [Checker] which checks the expiry of the certificates
+// presented by the tls endpoint at addr, of form "host:port".
+func Dial(addr string, opts ...Option) *Checker {
+	c := newChecker(opts)
+	c.load = func(ctx context.Context) ([]*x509.Certificate, error) {
+		return c.dial(ctx, addr)
+	}
+	return c
+}
+
+// File creates a [Checker] which checks the expiry of the pem encoded
+// certificates in the file at path. The file is read on each check, so
+// renewed certificates are picked up.
+func File(path string, opts ...Option) *Checker {
+	c := newChecker(opts)
+	c.load = func(context.Context) ([]*x509.Certificate, error) {
+		return readFile(path)
+	}
+	return c
+}
+
+func newChecker(opts []Option) *Checker {
+	c := Checker{
+		window:  DefaultWindow,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c
+}
+
+// CheckHealth checks the expiry of the certificates, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check retrieves the certificates and returns [healthcheck.StatusUnhealthy]
+// and an [ErrExpired] error when any of them is expired. When a certificate
+// expires within the window set with [WithWindow],
+// [healthcheck.StatusUnknown] is returned together with an [ErrExpiresSoon]
+// error.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	certs, err := c.load(ctx)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, err
+	}
+	if len(certs) == 0 {
+		return healthcheck.StatusUnhealthy, errors.New(ErrNoCertificates)
+	}
+
+	cert := certs[0]
+	for _, crt := range certs[1:] {
+		if crt.NotAfter.Before(cert.NotAfter) {
+			cert = crt
+		}
+	}
+
+	now := time.Now()
+	if now.After(cert.NotAfter) {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrExpired,
+			"certificate %q expired at %s", cert.Subject.CommonName, cert.NotAfter,
+		)
+	}
+	if now.Add(c.window).After(cert.NotAfter) {
+		return healthcheck.StatusUnknown, errors.Wrapf(ErrExpiresSoon,
+			"certificate %q expires at %s", cert.Subject.CommonName, cert.NotAfter,
+		)
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func (c *Checker) dial(ctx context.Context, addr string) ([]*x509.Certificate, error) {
+	var conf *tls.Config
+	if c.tlsConfig != nil {
+		conf = c.tlsConfig.Clone()
+	} else {
+		conf = new(tls.Config)
+	}
+	if conf.ServerName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			conf.ServerName = host
+		}
+	}
+	// certificates are only inspected, never trusted
+	conf.InsecureSkipVerify = true
+
+	d := tls.Dialer{Config: conf}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrDialFailed)
+	}
+	defer func() { _ = conn.Close() }()
+
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates, nil
+}
+
+func readFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrLoadFailed)
+	}
+
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, ErrLoadFailed)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
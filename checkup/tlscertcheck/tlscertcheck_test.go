@@ -0,0 +1,96 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tlscertcheck
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDial(t *testing.T) {
+	srv := httptest.NewTLSServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	stat, err := Dial(srv.Listener.Addr().String()).Check(context.Background())
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+
+	t.Run("failure", func(t *testing.T) {
+		stat, err := Dial("127.0.0.1:1").Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrDialFailed)
+	})
+}
+
+func TestFile(t *testing.T) {
+	tests := map[string]struct {
+		notAfter   time.Duration
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"valid":        {notAfter: 90 * 24 * time.Hour, wantStatus: healthcheck.StatusHealthy},
+		"expires soon": {notAfter: 7 * 24 * time.Hour, wantStatus: healthcheck.StatusUnknown, wantErr: ErrExpiresSoon},
+		"expired":      {notAfter: -time.Hour, wantStatus: healthcheck.StatusUnhealthy, wantErr: ErrExpired},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cert.pem")
+			writeCert(t, path, time.Now().Add(tc.notAfter))
+
+			stat, err := File(path).Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		stat, err := File(filepath.Join(t.TempDir(), "missing.pem")).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrLoadFailed)
+	})
+	t.Run("empty", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.pem")
+		assert.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		stat, err := File(path).Check(context.Background())
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.ErrorIs(t, err, ErrNoCertificates)
+	})
+}
+
+func writeCert(t *testing.T, path string, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tpl, &tpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	assert.NoError(t, os.WriteFile(path, data, 0o600))
+}
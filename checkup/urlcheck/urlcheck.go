@@ -0,0 +1,185 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package urlcheck provides a [healthcheck.HealthChecker] which checks the
+// availability of an arbitrary http endpoint, like a third party api. Use
+// [github.com/go-pogo/healthcheck/healthclient] to check the health of a
+// service which exposes a [healthcheck.HTTPHandler].
+package urlcheck
+
+import (
+	"context"
+	"io"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrInvalidURL           errors.Msg = "invalid url"
+	ErrRequestFailed        errors.Msg = "request failed"
+	ErrUnexpectedStatusCode errors.Msg = "unexpected status code"
+	ErrUnexpectedBody       errors.Msg = "unexpected response body"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+// maxBodySize is the maximum number of bytes read from a response body.
+const maxBodySize = 1 << 20
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the availability of a http endpoint.
+type Checker struct {
+	url         *urlpkg.URL
+	httpClient  *http.Client
+	method      string
+	header      http.Header
+	codes       map[int]struct{}
+	bodyMatcher func(body []byte) bool
+	timeout     time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithHTTPClient sets the [http.Client] used to perform requests. The default
+// is [http.DefaultClient].
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Checker) { c.httpClient = client }
+}
+
+// WithMethod sets the http method of the requests. The default method is
+// [http.MethodGet].
+func WithMethod(method string) Option {
+	return func(c *Checker) { c.method = method }
+}
+
+// WithHeader adds a header to the requests.
+func WithHeader(key, value string) Option {
+	return func(c *Checker) {
+		if c.header == nil {
+			c.header = make(http.Header, 2)
+		}
+		c.header.Add(key, value)
+	}
+}
+
+// WithStatusCodes sets the status codes which indicate the endpoint is
+// available. By default, any 2xx status code is expected.
+func WithStatusCodes(codes ...int) Option {
+	return func(c *Checker) {
+		if c.codes == nil {
+			c.codes = make(map[int]struct{}, len(codes))
+		}
+		for _, code := range codes {
+			c.codes[code] = struct{}{}
+		}
+	}
+}
+
+// WithBodyMatcher validates the body of responses with an expected status
+// code using fn.
+func WithBodyMatcher(fn func(body []byte) bool) Option {
+	return func(c *Checker) { c.bodyMatcher = fn }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the availability of the http endpoint
+// at rawURL. It returns an error wrapping [ErrInvalidURL] when rawURL is not
+// an absolute url.
+func New(rawURL string, opts ...Option) (*Checker, error) {
+	url, err := urlpkg.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidURL)
+	}
+	if url.Scheme == "" || url.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidURL, "url %q must be absolute", rawURL)
+	}
+
+	c := Checker{
+		url:     url,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c, nil
+}
+
+// CheckHealth checks the availability of the endpoint, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check performs a request to the endpoint and returns
+// [healthcheck.StatusUnhealthy] and an error when the request fails, the
+// response has an unexpected status code or the body does not match.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url.String(), nil)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+	for key, vals := range c.header {
+		req.Header[key] = append(req.Header[key], vals...)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrRequestFailed)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if !c.expectStatusCode(resp.StatusCode) {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrUnexpectedStatusCode, "status code %d", resp.StatusCode)
+	}
+	if c.bodyMatcher == nil {
+		return healthcheck.StatusHealthy, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrRequestFailed)
+	}
+	if !c.bodyMatcher(body) {
+		return healthcheck.StatusUnhealthy, errors.New(ErrUnexpectedBody)
+	}
+	return healthcheck.StatusHealthy, nil
+}
+
+func (c *Checker) expectStatusCode(code int) bool {
+	if c.codes == nil {
+		return code >= 200 && code < 300
+	}
+	_, ok := c.codes[code]
+	return ok
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package urlcheck
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	for _, rawURL := range []string{"/status", "api.example.com", "://"} {
+		t.Run(rawURL, func(t *testing.T) {
+			_, err := New(rawURL)
+			assert.ErrorIs(t, err, ErrInvalidURL)
+		})
+	}
+}
+
+func TestChecker_Check(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "secret" {
+			wri.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if req.Method == http.MethodHead {
+			wri.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = wri.Write([]byte(`{"status":"operational"}`))
+	}))
+	defer srv.Close()
+
+	auth := WithHeader("Authorization", "secret")
+
+	tests := map[string]struct {
+		url        string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"available": {
+			opts:       []Option{auth},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"method": {
+			opts:       []Option{auth, WithMethod(http.MethodHead), WithStatusCodes(http.StatusNoContent)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"unexpected status code": {
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedStatusCode,
+		},
+		"expected status code": {
+			opts:       []Option{WithStatusCodes(http.StatusUnauthorized)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"body match": {
+			opts: []Option{auth, WithBodyMatcher(func(body []byte) bool {
+				return bytes.Contains(body, []byte("operational"))
+			})},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"body mismatch": {
+			opts: []Option{auth, WithBodyMatcher(func(body []byte) bool {
+				return bytes.Contains(body, []byte("maintenance"))
+			})},
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedBody,
+		},
+		"request failure": {
+			url:        "http://127.0.0.1:1",
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrRequestFailed,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			url := tc.url
+			if url == "" {
+				url = srv.URL
+			}
+
+			check, err := New(url, tc.opts...)
+			assert.NoError(t, err)
+
+			stat, err := check.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package vaultcheck provides a [healthcheck.HealthChecker] which checks the
+// seal and health status of a HashiCorp Vault server using its /v1/sys/health
+// api.
+package vaultcheck
+
+import (
+	"context"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrInvalidURL           errors.Msg = "invalid url"
+	ErrRequestFailed        errors.Msg = "request failed"
+	ErrUnexpectedStatusCode errors.Msg = "unexpected status code"
+	ErrSealed               errors.Msg = "vault is sealed"
+	ErrNotInitialized       errors.Msg = "vault is not initialized"
+	ErrStandby              errors.Msg = "vault is in standby"
+	ErrDRSecondary          errors.Msg = "vault is a disaster recovery secondary"
+)
+
+// DefaultTimeout is the default maximum duration of a single health check.
+const DefaultTimeout = 5 * time.Second
+
+// Status codes returned by the /v1/sys/health api, in addition to
+// [http.StatusOK] for an initialized, unsealed and active node.
+const (
+	statusStandby        = http.StatusTooManyRequests
+	statusDRSecondary    = 472
+	statusPerfStandby    = 473
+	statusNotInitialized = http.StatusNotImplemented
+	statusSealed         = http.StatusServiceUnavailable
+)
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker checks the seal and health status of a Vault server.
+type Checker struct {
+	url             *urlpkg.URL
+	httpClient      *http.Client
+	standbyDegraded bool
+	timeout         time.Duration
+}
+
+// Option is an option for a [Checker] created with [New].
+type Option func(c *Checker)
+
+// WithHTTPClient sets the [http.Client] used to perform requests. The default
+// is [http.DefaultClient].
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Checker) { c.httpClient = client }
+}
+
+// WithStandbyDegraded treats a (performance) standby node as degraded instead
+// of healthy.
+func WithStandbyDegraded() Option {
+	return func(c *Checker) { c.standbyDegraded = true }
+}
+
+// WithTimeout sets the maximum duration of a single health check. The default
+// is [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) { c.timeout = timeout }
+}
+
+// New creates a [Checker] which checks the Vault server at addr, e.g.
+// "https://vault.example.org:8200". It returns an error wrapping
+// [ErrInvalidURL] when addr is not an absolute url.
+func New(addr string, opts ...Option) (*Checker, error) {
+	url, err := urlpkg.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrInvalidURL)
+	}
+	if url.Scheme == "" || url.Host == "" {
+		return nil, errors.Wrapf(ErrInvalidURL, "url %q must be absolute", addr)
+	}
+
+	c := Checker{
+		url:     url.JoinPath("v1", "sys", "health"),
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&c)
+		}
+	}
+	return &c, nil
+}
+
+// CheckHealth checks the status of the Vault server, see [Checker.Check].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+// Check requests the health of the Vault server. An active node is
+// [healthcheck.StatusHealthy], as is a standby node unless
+// [WithStandbyDegraded] is used. A sealed or uninitialized node, or a failed
+// request, results in [healthcheck.StatusUnhealthy] and an error. A disaster
+// recovery secondary node results in [healthcheck.StatusUnknown] and an
+// error.
+func (c *Checker) Check(ctx context.Context) (healthcheck.Status, error) {
+	if c.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, c.timeout)
+		defer cancelFn()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return healthcheck.StatusUnhealthy, errors.Wrap(err, ErrRequestFailed)
+	}
+	_ = resp.Body.Close()
+
+	return c.status(resp.StatusCode)
+}
+
+func (c *Checker) status(code int) (healthcheck.Status, error) {
+	switch code {
+	case http.StatusOK:
+		return healthcheck.StatusHealthy, nil
+	case statusStandby, statusPerfStandby:
+		if c.standbyDegraded {
+			return healthcheck.StatusUnknown, errors.New(ErrStandby)
+		}
+		return healthcheck.StatusHealthy, nil
+	case statusDRSecondary:
+		return healthcheck.StatusUnknown, errors.New(ErrDRSecondary)
+	case statusSealed:
+		return healthcheck.StatusUnhealthy, errors.New(ErrSealed)
+	case statusNotInitialized:
+		return healthcheck.StatusUnhealthy, errors.New(ErrNotInitialized)
+	default:
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrUnexpectedStatusCode, "status code %d", code)
+	}
+}
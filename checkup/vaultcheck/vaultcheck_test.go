@@ -0,0 +1,84 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vaultcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New("vault:8200")
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := map[string]struct {
+		code       int
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"active": {
+			code:       http.StatusOK,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"standby": {
+			code:       statusStandby,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"standby degraded": {
+			code:       statusPerfStandby,
+			opts:       []Option{WithStandbyDegraded()},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrStandby,
+		},
+		"dr secondary": {
+			code:       statusDRSecondary,
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrDRSecondary,
+		},
+		"sealed": {
+			code:       statusSealed,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrSealed,
+		},
+		"not initialized": {
+			code:       statusNotInitialized,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrNotInitialized,
+		},
+		"unexpected": {
+			code:       http.StatusInternalServerError,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrUnexpectedStatusCode,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/v1/sys/health", req.URL.Path)
+				wri.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			c, err := New(srv.URL, tc.opts...)
+			assert.NoError(t, err)
+
+			stat, err := c.Check(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+const ErrCircuitOpen errors.Msg = "circuit open"
+
+var _ HealthChecker = (*CircuitBreakerChecker)(nil)
+
+// CircuitBreakerChecker wraps a [HealthChecker] and stops calling it for a
+// cool-down period after it repeatedly did not return [StatusHealthy]. This
+// protects a struggling dependency from being probed into the ground.
+type CircuitBreakerChecker struct {
+	HealthChecker
+	// Threshold is the number of consecutive non-healthy results after which
+	// the circuit opens.
+	Threshold int
+	// Cooldown is the duration the circuit stays open before the wrapped
+	// [HealthChecker] is called again.
+	Cooldown time.Duration
+
+	mut      sync.Mutex
+	failures int
+	stat     Status
+	openedAt time.Time
+}
+
+// CircuitBreaker wraps check in a [CircuitBreakerChecker] which opens the
+// circuit after threshold consecutive non-healthy results of check. While
+// open, check is not called and its last [Status] is returned. After
+// cooldown, check is called once more: the circuit closes when it returns
+// [StatusHealthy] and reopens otherwise.
+func CircuitBreaker(check HealthChecker, threshold int, cooldown time.Duration) *CircuitBreakerChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	return &CircuitBreakerChecker{
+		HealthChecker: check,
+		Threshold:     threshold,
+		Cooldown:      cooldown,
+	}
+}
+
+// CheckHealth checks the health status, see [CircuitBreakerChecker.Check].
+func (cb *CircuitBreakerChecker) CheckHealth(ctx context.Context) Status {
+	stat, _ := cb.Check(ctx)
+	return stat
+}
+
+// Check calls the wrapped [HealthChecker] when the circuit is closed, or when
+// the cool-down period of the open circuit has passed. While the circuit is
+// open, it returns the last [Status] of the wrapped [HealthChecker] and an
+// error wrapping [ErrCircuitOpen].
+func (cb *CircuitBreakerChecker) Check(ctx context.Context) (Status, error) {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+
+	if cb.open() {
+		return cb.stat, errors.Wrapf(ErrCircuitOpen, "%d consecutive failures, retry in %s",
+			cb.failures, time.Until(cb.openedAt.Add(cb.Cooldown)).Round(time.Millisecond),
+		)
+	}
+
+	cb.stat = cb.HealthChecker.CheckHealth(ctx)
+	if cb.stat == StatusHealthy {
+		cb.failures = 0
+		cb.openedAt = time.Time{}
+		return cb.stat, nil
+	}
+
+	cb.failures++
+	if cb.Threshold > 0 && cb.failures >= cb.Threshold {
+		cb.openedAt = time.Now()
+	}
+	return cb.stat, nil
+}
+
+// Open indicates whether the circuit is currently open.
+func (cb *CircuitBreakerChecker) Open() bool {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+	return cb.open()
+}
+
+func (cb *CircuitBreakerChecker) open() bool {
+	return !cb.openedAt.IsZero() && time.Since(cb.openedAt) < cb.Cooldown
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		CircuitBreaker(nil, 3, time.Second)
+	})
+
+	var calls int
+	stat := StatusUnhealthy
+	cb := CircuitBreaker(HealthCheckerFunc(func(context.Context) Status {
+		calls++
+		return stat
+	}), 2, 50*time.Millisecond)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		have, err := cb.Check(ctx)
+		assert.Equal(t, StatusUnhealthy, have)
+		assert.NoError(t, err)
+	}
+	assert.True(t, cb.Open(), "circuit should open after threshold")
+
+	have, err := cb.Check(ctx)
+	assert.Equal(t, StatusUnhealthy, have)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls, "check should not be called while open")
+
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, cb.Open(), "circuit should allow a call after cooldown")
+
+	have, err = cb.Check(ctx)
+	assert.Equal(t, StatusUnhealthy, have)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.True(t, cb.Open(), "circuit should reopen when still failing")
+
+	time.Sleep(60 * time.Millisecond)
+	stat = StatusHealthy
+	assert.Equal(t, StatusHealthy, cb.CheckHealth(ctx))
+	assert.False(t, cb.Open())
+
+	stat = StatusUnhealthy
+	assert.Equal(t, StatusUnhealthy, cb.CheckHealth(ctx))
+	assert.False(t, cb.Open(), "failures should reset after a healthy result")
+}
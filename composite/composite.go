@@ -0,0 +1,136 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package composite provides a [healthcheck.HealthChecker] which derives its
+// [healthcheck.Status] from the statuses of named
+// [healthcheck.HealthChecker](s) registered to a [healthcheck.Checker], using
+// an expression like:
+//
+//	composite.All("db", composite.Any("cache-a", "cache-b"))
+//
+// This way redundancy rules are declared rather than hand-coded.
+package composite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pogo/healthcheck"
+)
+
+// Resolver resolves a name to the [healthcheck.Status] of the
+// [healthcheck.HealthChecker] registered with that name. It returns false
+// when no [healthcheck.HealthChecker] is registered with the name.
+// [healthcheck.Checker] implements Resolver.
+type Resolver interface {
+	CheckHealthOf(ctx context.Context, name string) (healthcheck.Status, bool)
+}
+
+// Expr is an expression which evaluates to a [healthcheck.Status].
+type Expr interface {
+	eval(ctx context.Context, r Resolver) healthcheck.Status
+}
+
+type name string
+
+// eval returns [healthcheck.StatusUnknown] when no [healthcheck.HealthChecker]
+// is registered with the name.
+func (n name) eval(ctx context.Context, r Resolver) healthcheck.Status {
+	stat, _ := r.CheckHealthOf(ctx, string(n))
+	return stat
+}
+
+type all []Expr
+
+func (a all) eval(ctx context.Context, r Resolver) healthcheck.Status {
+	res := healthcheck.StatusHealthy
+	for _, expr := range a {
+		switch expr.eval(ctx, r) {
+		case healthcheck.StatusUnhealthy:
+			return healthcheck.StatusUnhealthy
+		case healthcheck.StatusUnknown:
+			res = healthcheck.StatusUnknown
+		}
+	}
+	return res
+}
+
+type anyOf []Expr
+
+func (a anyOf) eval(ctx context.Context, r Resolver) healthcheck.Status {
+	res := healthcheck.StatusUnhealthy
+	for _, expr := range a {
+		switch expr.eval(ctx, r) {
+		case healthcheck.StatusHealthy:
+			return healthcheck.StatusHealthy
+		case healthcheck.StatusUnknown:
+			res = healthcheck.StatusUnknown
+		}
+	}
+	return res
+}
+
+// All returns an [Expr] which is [healthcheck.StatusHealthy] when all of its
+// operands are healthy, and [healthcheck.StatusUnhealthy] when any of its
+// operands is unhealthy. Otherwise, it is [healthcheck.StatusUnknown]. An
+// operand is either the name of a registered [healthcheck.HealthChecker], or
+// another [Expr].
+func All(operands ...any) Expr { return all(toExprs("All", operands)) }
+
+// Any returns an [Expr] which is [healthcheck.StatusHealthy] when any of its
+// operands is healthy, and [healthcheck.StatusUnhealthy] when all of its
+// operands are unhealthy. Otherwise, it is [healthcheck.StatusUnknown]. An
+// operand is either the name of a registered [healthcheck.HealthChecker], or
+// another [Expr].
+func Any(operands ...any) Expr { return anyOf(toExprs("Any", operands)) }
+
+func toExprs(fn string, operands []any) []Expr {
+	if len(operands) == 0 {
+		panic("composite." + fn + ": at least one operand is required")
+	}
+
+	exprs := make([]Expr, len(operands))
+	for i, op := range operands {
+		switch v := op.(type) {
+		case string:
+			exprs[i] = name(v)
+		case Expr:
+			exprs[i] = v
+		default:
+			panic(fmt.Sprintf("composite.%s: operand %d should be a string or Expr, not %T", fn, i, op))
+		}
+	}
+	return exprs
+}
+
+var _ healthcheck.HealthChecker = (*Checker)(nil)
+
+// Checker is a [healthcheck.HealthChecker] which evaluates an [Expr].
+type Checker struct {
+	resolver Resolver
+	expr     Expr
+}
+
+const (
+	panicNilResolver = "composite.New: Resolver should not be nil"
+	panicNilExpr     = "composite.New: Expr should not be nil"
+)
+
+// New creates a [Checker] which evaluates expr by resolving its names using
+// r. Names are resolved by calling r.CheckHealthOf, therefore the [Checker]
+// should not be registered to the same [healthcheck.Checker] as r.
+func New(r Resolver, expr Expr) *Checker {
+	if r == nil {
+		panic(panicNilResolver)
+	}
+	if expr == nil {
+		panic(panicNilExpr)
+	}
+	return &Checker{resolver: r, expr: expr}
+}
+
+// CheckHealth evaluates the [Expr].
+func (c *Checker) CheckHealth(ctx context.Context) healthcheck.Status {
+	return c.expr.eval(ctx, c.resolver)
+}
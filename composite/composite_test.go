@@ -0,0 +1,94 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package composite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+type statuses map[string]healthcheck.Status
+
+func (s statuses) CheckHealthOf(_ context.Context, name string) (healthcheck.Status, bool) {
+	stat, ok := s[name]
+	return stat, ok
+}
+
+func TestNew(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilResolver, func() {
+		_ = New(nil, All("db"))
+	})
+	assert.PanicsWithValue(t, panicNilExpr, func() {
+		_ = New(statuses{}, nil)
+	})
+}
+
+func TestOperands(t *testing.T) {
+	assert.Panics(t, func() { All() })
+	assert.Panics(t, func() { Any("db", 1) })
+}
+
+func TestChecker_CheckHealth(t *testing.T) {
+	const (
+		healthy   = healthcheck.StatusHealthy
+		unhealthy = healthcheck.StatusUnhealthy
+		unknown   = healthcheck.StatusUnknown
+	)
+
+	expr := All("db", Any("cache-a", "cache-b"))
+	tests := map[string]struct {
+		stats statuses
+		want  healthcheck.Status
+	}{
+		"all healthy": {
+			stats: statuses{"db": healthy, "cache-a": healthy, "cache-b": healthy},
+			want:  healthy,
+		},
+		"one cache unhealthy": {
+			stats: statuses{"db": healthy, "cache-a": unhealthy, "cache-b": healthy},
+			want:  healthy,
+		},
+		"all caches unhealthy": {
+			stats: statuses{"db": healthy, "cache-a": unhealthy, "cache-b": unhealthy},
+			want:  unhealthy,
+		},
+		"cache unknown": {
+			stats: statuses{"db": healthy, "cache-a": unhealthy, "cache-b": unknown},
+			want:  unknown,
+		},
+		"db unhealthy": {
+			stats: statuses{"db": unhealthy, "cache-a": healthy, "cache-b": healthy},
+			want:  unhealthy,
+		},
+		"db not registered": {
+			stats: statuses{"cache-a": healthy},
+			want:  unknown,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, New(tc.stats, expr).CheckHealth(context.Background()))
+		})
+	}
+}
+
+func TestChecker_CheckHealth_checker(t *testing.T) {
+	deps, err := healthcheck.New(
+		healthcheck.WithHealthChecker("db", healthcheck.HealthCheckerFunc(func(context.Context) healthcheck.Status {
+			return healthcheck.StatusHealthy
+		})),
+		healthcheck.WithHealthChecker("cache-a", healthcheck.HealthCheckerFunc(func(context.Context) healthcheck.Status {
+			return healthcheck.StatusUnhealthy
+		})),
+	)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.Equal(t, healthcheck.StatusHealthy, New(deps, Any("db", "cache-a")).CheckHealth(ctx))
+	assert.Equal(t, healthcheck.StatusUnhealthy, New(deps, All("db", "cache-a")).CheckHealth(ctx))
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const panicNilDebugChecker = "healthcheck.DebugHandler: Checker should not be nil"
+
+// DebugHandler returns a [http.Handler] which responds with a json object
+// describing the configuration of checker, like its timeout and parallelism,
+// and each registered [HealthChecker] with its options and the timing of its
+// last run. The options of the decorators in this package, e.g.
+// [RetryChecker] and [AsyncChecker], are included in the "chain" of each
+// check.
+//
+// The handler does not trigger any health checks. It may expose internal
+// details, so make sure it is not publicly accessible.
+func DebugHandler(checker *Checker) http.Handler {
+	if checker == nil {
+		panic(panicNilDebugChecker)
+	}
+
+	return http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(wri)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(checker.debugInfo())
+	})
+}
+
+type debugInfo struct {
+	Status       Status       `json:"status"`
+	Timeout      string       `json:"timeout"`
+	Parallel     bool         `json:"parallel"`
+	AuditLog     bool         `json:"audit_log"`
+	EventSinks   int          `json:"event_sinks"`
+	Interceptors int          `json:"interceptors"`
+	Checks       []debugCheck `json:"checks"`
+}
+
+type debugCheck struct {
+	Name     string       `json:"name"`
+	Status   Status       `json:"status"`
+	Duration string       `json:"duration,omitempty"`
+	LastRun  *time.Time   `json:"last_run,omitempty"`
+	Logger   bool         `json:"logger"`
+	Chain    []debugLayer `json:"chain"`
+}
+
+type debugLayer struct {
+	Type    string            `json:"type"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+func (h *Checker) debugInfo() debugInfo {
+	h.mut.RLock()
+	defer h.mut.RUnlock()
+
+	res := debugInfo{
+		Status:       h.status.Load(),
+		Timeout:      h.Timeout.String(),
+		Parallel:     h.Parallel,
+		AuditLog:     h.audit != nil,
+		EventSinks:   len(h.sinks),
+		Interceptors: len(h.interceptors),
+		Checks:       make([]debugCheck, 0, len(h.checks)),
+	}
+
+	for name, check := range h.checks {
+		dc := debugCheck{
+			Name:  name,
+			Chain: debugChain(check),
+		}
+		_, dc.Logger = h.checkLogs[name]
+		if r, ok := h.results[name]; ok {
+			dc.Status = r.Status
+			dc.Duration = r.Duration.String()
+			dc.LastRun = &r.Time
+		}
+		res.Checks = append(res.Checks, dc)
+	}
+
+	sort.Slice(res.Checks, func(i, j int) bool {
+		return res.Checks[i].Name < res.Checks[j].Name
+	})
+	return res
+}
+
+// debugChain describes check and, when it is a decorator from this package,
+// the [HealthChecker](s) it wraps.
+func debugChain(check HealthChecker) []debugLayer {
+	var res []debugLayer
+	for check != nil {
+		layer := debugLayer{Type: fmt.Sprintf("%T", check)}
+
+		var next HealthChecker
+		switch c := check.(type) {
+		case *TimeoutChecker:
+			next = c.HealthChecker
+			layer.Options = map[string]string{
+				"timeout":        c.Timeout.String(),
+				"timeout_status": c.TimeoutStatus.String(),
+			}
+		case *CachedChecker:
+			next = c.HealthChecker
+			layer.Options = map[string]string{"ttl": c.TTL.String()}
+		case *RetryChecker:
+			next = c.HealthChecker
+			layer.Options = map[string]string{
+				"attempts": strconv.Itoa(c.Attempts),
+				"backoff":  c.Backoff.String(),
+			}
+		case *CircuitBreakerChecker:
+			next = c.HealthChecker
+			layer.Options = map[string]string{
+				"threshold": strconv.Itoa(c.Threshold),
+				"cooldown":  c.Cooldown.String(),
+				"open":      strconv.FormatBool(c.Open()),
+			}
+		case *AsyncChecker:
+			next = c.check
+			layer.Options = map[string]string{"interval": c.interval.String()}
+		case *RateLimitedChecker:
+			next = c.HealthChecker
+			layer.Options = map[string]string{"min_interval": c.MinInterval.String()}
+		}
+
+		res = append(res, layer)
+		check = next
+	}
+	return res
+}
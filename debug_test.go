@@ -0,0 +1,59 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugHandler(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilDebugChecker, func() {
+			_ = DebugHandler(nil)
+		})
+	})
+
+	c, err := New(
+		WithCheckLogger("db", NopLogger()),
+		WithHealthChecker("db", Retry(Cached(new(alwaysHealty), time.Minute), 3, time.Second)),
+		WithHealthChecker("api", staticStatus(StatusUnhealthy)),
+	)
+	assert.NoError(t, err)
+	c.CheckHealth(context.Background())
+
+	rec := httptest.NewRecorder()
+	DebugHandler(c).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var have debugInfo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &have))
+	assert.Equal(t, StatusUnhealthy, have.Status)
+	assert.Equal(t, "3s", have.Timeout)
+	assert.Len(t, have.Checks, 2)
+
+	api := have.Checks[0]
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, StatusUnhealthy, api.Status)
+	assert.False(t, api.Logger)
+	assert.NotNil(t, api.LastRun)
+	assert.Equal(t, []debugLayer{{Type: "healthcheck.HealthCheckerFunc"}}, api.Chain)
+
+	db := have.Checks[1]
+	assert.Equal(t, "db", db.Name)
+	assert.True(t, db.Logger)
+	assert.Equal(t, []debugLayer{
+		{Type: "*healthcheck.RetryChecker", Options: map[string]string{"attempts": "3", "backoff": "1s"}},
+		{Type: "*healthcheck.CachedChecker", Options: map[string]string{"ttl": "1m0s"}},
+		{Type: "*healthcheck.alwaysHealty"},
+	}, db.Chain)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+// EventKind describes the kind of [Event].
+type EventKind uint8
+
+const (
+	// EventCheckCompleted indicates a registered [HealthChecker] completed
+	// its health check.
+	EventCheckCompleted EventKind = iota + 1
+	// EventHealthChanged indicates the combined health [Status] of a
+	// [Checker] changed.
+	EventHealthChanged
+)
+
+// String returns a string representation of [EventKind].
+func (k EventKind) String() string {
+	switch k {
+	case EventCheckCompleted:
+		return "check_completed"
+	case EventHealthChanged:
+		return "health_changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes something that happened within a [Checker].
+type Event struct {
+	Kind EventKind
+	// Name is the name of the [HealthChecker] the event relates to. It is
+	// empty for events which relate to the [Checker] as a whole.
+	Name      string
+	OldStatus Status
+	Status    Status
+	// Err is the error returned by a [HealthChecker] which, next to
+	// CheckHealth, also has a Check(ctx) (Status, error) method, like the
+	// checkers in the checkup package.
+	Err error
+	// Checks contains the [Status] of each registered [HealthChecker] for
+	// [EventHealthChanged] events.
+	Checks   map[string]Status
+	Duration time.Duration
+	Time     time.Time
+}
+
+// EventSink receives [Event](s) emitted by a [Checker]. Events may be emitted
+// concurrently, an EventSink should therefore be safe for concurrent use. It
+// should not call any of the [Checker]'s methods.
+type EventSink interface {
+	HandleEvent(e Event)
+}
+
+// EventSinkFunc receives [Event](s) emitted by a [Checker].
+type EventSinkFunc func(e Event)
+
+func (fn EventSinkFunc) HandleEvent(e Event) { fn(e) }
+
+const panicNilEventSink = "healthcheck.WithEventSink: EventSink should not be nil"
+
+// WithEventSink adds an [EventSink] to the [Checker], which receives all
+// [Event](s) emitted by the [Checker]. Loggers, listeners, webhooks and
+// metrics exporters can all be implemented as an [EventSink].
+func WithEventSink(sink EventSink) Option {
+	if sink == nil {
+		panic(panicNilEventSink)
+	}
+
+	return func(c *Checker) error {
+		c.sinks = append(c.sinks, sink)
+		return nil
+	}
+}
+
+// errorChecker is implemented by [HealthChecker](s) which are also able to
+// return the error that caused their [Status].
+type errorChecker interface {
+	Check(ctx context.Context) (Status, error)
+}
+
+// runCheck runs check, via the [Interceptor](s) of the [Checker], and emits
+// an [EventCheckCompleted] event. The returned [Result] contains the
+// resulting [Status] and the duration of the check. When available, the
+// [CheckLogger] of the check is notified before and after the run.
+func (h *Checker) runCheck(ctx context.Context, name string, check HealthChecker, oldStatus Status) Result {
+	log := h.checkLogger(name)
+	if log != nil {
+		log.LogCheckStarted(name)
+	}
+
+	start := time.Now()
+	var res Result
+	res.Status, res.Err = h.intercept(ctx, name, checkFunc(check))
+	res.Duration = time.Since(start)
+	res.Time = start
+
+	if len(h.sinks) != 0 {
+		h.emit(Event{
+			Kind:      EventCheckCompleted,
+			Name:      name,
+			OldStatus: oldStatus,
+			Status:    res.Status,
+			Err:       res.Err,
+			Duration:  res.Duration,
+			Time:      start,
+		})
+	}
+	if log != nil {
+		log.LogCheckCompleted(name, res)
+	}
+	return res
+}
+
+func (h *Checker) emit(e Event) {
+	for _, sink := range h.sinks {
+		sink.HandleEvent(e)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type errorCheck struct {
+	stat Status
+	err  error
+}
+
+func (c *errorCheck) CheckHealth(ctx context.Context) Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+func (c *errorCheck) Check(context.Context) (Status, error) { return c.stat, c.err }
+
+type eventRecorder struct {
+	mut    sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) HandleEvent(e Event) {
+	r.mut.Lock()
+	r.events = append(r.events, e)
+	r.mut.Unlock()
+}
+
+func TestWithEventSink(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilEventSink, func() {
+			_ = WithEventSink(nil)
+		})
+	})
+
+	t.Run("events", func(t *testing.T) {
+		wantErr := errors.New("connection refused")
+
+		var rec eventRecorder
+		c, err := New(
+			WithEventSink(&rec),
+			WithHealthChecker("db", &errorCheck{stat: StatusUnhealthy, err: wantErr}),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusUnhealthy, c.CheckHealth(context.Background()))
+
+		assert.Len(t, rec.events, 2)
+		check, changed := rec.events[0], rec.events[1]
+
+		assert.Equal(t, EventCheckCompleted, check.Kind)
+		assert.Equal(t, "db", check.Name)
+		assert.Equal(t, StatusUnknown, check.OldStatus)
+		assert.Equal(t, StatusUnhealthy, check.Status)
+		assert.Same(t, wantErr, check.Err)
+		assert.False(t, check.Time.IsZero())
+
+		assert.Equal(t, EventHealthChanged, changed.Kind)
+		assert.Equal(t, "", changed.Name)
+		assert.Equal(t, StatusUnknown, changed.OldStatus)
+		assert.Equal(t, StatusUnhealthy, changed.Status)
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		var rec eventRecorder
+		c, err := New(WithEventSink(&rec))
+		assert.NoError(t, err)
+		c.Parallel = true
+
+		names := []string{"a", "b", "c"}
+		for _, name := range names {
+			c.Register(name, &errorCheck{stat: StatusHealthy})
+		}
+		assert.Equal(t, StatusHealthy, c.CheckHealth(context.Background()))
+
+		_, ok := c.CheckHealthOf(context.Background(), "a")
+		assert.True(t, ok)
+
+		have := make(map[string]int, len(names))
+		for _, e := range rec.events {
+			if e.Kind == EventCheckCompleted {
+				have[e.Name]++
+			}
+		}
+		assert.Equal(t, map[string]int{"a": 2, "b": 1, "c": 1}, have)
+		assert.Equal(t, StatusHealthy, rec.events[len(rec.events)-1].OldStatus)
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"encoding/json"
+	"expvar"
+)
+
+const panicNilVarChecker = "healthcheck.Var: Checker should not be nil"
+
+// Var returns an [expvar.Var] which reports the current health [Status] of
+// checker and the [Result] of each registered [HealthChecker], see
+// [Checker.Results]. It does not trigger any health checks. Publish it to
+// make it available under "/debug/vars".
+//
+//	expvar.Publish("health", healthcheck.Var(checker))
+func Var(checker *Checker) expvar.Var {
+	if checker == nil {
+		panic(panicNilVarChecker)
+	}
+	return &healthVar{checker}
+}
+
+type healthVar struct{ checker *Checker }
+
+type varValue struct {
+	Status Status                   `json:"status"`
+	Checks map[string]verboseResult `json:"checks"`
+}
+
+func (v *healthVar) String() string {
+	b, _ := json.Marshal(varValue{
+		Status: v.checker.Status(),
+		Checks: verboseResults(v.checker.Results()),
+	})
+	return string(b)
+}
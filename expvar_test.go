@@ -0,0 +1,36 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVar(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilVarChecker, func() {
+			_ = Var(nil)
+		})
+	})
+
+	c, err := New(
+		WithHealthChecker("db", new(alwaysHealty)),
+		WithHealthChecker("api", staticStatus(StatusUnhealthy)),
+	)
+	assert.NoError(t, err)
+	c.CheckHealth(context.Background())
+
+	var have varValue
+	assert.NoError(t, json.Unmarshal([]byte(Var(c).String()), &have))
+	assert.Equal(t, StatusUnhealthy, have.Status)
+	assert.Len(t, have.Checks, 2)
+	assert.Equal(t, StatusHealthy, have.Checks["db"].Status)
+	assert.Equal(t, StatusUnhealthy, have.Checks["api"].Status)
+	assert.NotEmpty(t, have.Checks["api"].Duration)
+}
@@ -5,12 +5,30 @@
 package healthcheck
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	// PathPattern is the default path for a http handler.
+	PathPattern = "/healthy"
+	// StatusHeader is the http header which contains the actual [Status]
+	// when using a http handler created with [HTTPHandler].
+	StatusHeader = "X-Health-Status"
+	// TimeoutHeader is the http header a client may use to request a shorter
+	// timeout for the health check, see [WithRequestTimeout].
+	TimeoutHeader = "X-Health-Timeout"
+	// TimeoutQueryParam is the query parameter a client may use to request a
+	// shorter timeout for the health check, see [WithRequestTimeout].
+	TimeoutQueryParam = "timeout"
 )
 
-// PathPattern is the default path for a http handler.
-const PathPattern = "/healthy"
+const ErrInvalidTimeout errors.Msg = "invalid timeout"
 
 var okBytes = []byte("ok")
 
@@ -21,36 +39,283 @@ func SimpleHTTPHandler() http.Handler {
 	})
 }
 
+// HandlerOption is an option for a [http.Handler] created with [HTTPHandler].
+type HandlerOption func(h *handler)
+
+// WithAlwaysOK makes the handler always respond with http status code 200,
+// regardless of the actual [Status]. The actual [Status] is still available
+// from the [StatusHeader] header and the response body. This prevents load
+// balancers from ejecting instances that are not [StatusHealthy].
+func WithAlwaysOK() HandlerOption {
+	return func(h *handler) { h.alwaysOK = true }
+}
+
+// WithVerbose makes the handler always respond with a json object containing
+// the [Status] and duration of each registered [HealthChecker], see
+// [Checker.Results], also when the health status is [StatusHealthy]. This
+// option has no effect when the handler's [HealthChecker] is not a [Checker].
+//
+//	{"db":{"status":1,"duration":"1.2ms"},"api":{"status":-1,"duration":"3s"}}
+func WithVerbose() HandlerOption {
+	return func(h *handler) { h.verbose = true }
+}
+
+// WithRequestTimeout allows clients to request a timeout for the health check
+// using the [TimeoutQueryParam] query parameter or [TimeoutHeader] header, for
+// example "?timeout=1s". The requested timeout is capped by max. A request
+// with an invalid timeout value is responded to with http status code 400.
+func WithRequestTimeout(max time.Duration) HandlerOption {
+	return func(h *handler) { h.maxTimeout = max }
+}
+
+// WithCORS adds CORS headers to the responses of the handler, allowing
+// browser based clients from any of the provided origins to access the health
+// status. Use "*" to allow any origin. When no methods are provided, GET, HEAD
+// and OPTIONS are allowed. Preflight requests are responded to with http
+// status code 204.
+func WithCORS(origins []string, methods ...string) HandlerOption {
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+	}
+	return func(h *handler) {
+		h.corsOrigins = origins
+		h.corsMethods = strings.Join(methods, ", ")
+	}
+}
+
+// WithCheckRoutes enables sub-routes of form "prefix/{name}" which check and
+// report only the status of the [HealthChecker] registered with name in the
+// [Checker]. Unknown names are responded to with http status code 404. This
+// option has no effect when the handler's [HealthChecker] is not a [Checker].
+//
+//	h := HTTPHandler(checker, WithCheckRoutes(PathPattern))
+//	mux.Handle(PathPattern, h)
+//	mux.Handle(PathPattern+"/", h)
+func WithCheckRoutes(prefix string) HandlerOption {
+	return func(h *handler) { h.routesPrefix = strings.TrimSuffix(prefix, "/") + "/" }
+}
+
+// WithHistoryRoute enables a route at path, e.g. "/healthy/history", which
+// responds with a json array of the entries of the [Checker]'s audit log,
+// see [WithAuditLog]. This option has no effect when the handler's
+// [HealthChecker] is not a [Checker]. The route takes precedence over the
+// routes enabled with [WithCheckRoutes].
+func WithHistoryRoute(path string) HandlerOption {
+	return func(h *handler) { h.historyPath = path }
+}
+
+// Middleware wraps a [http.Handler] with additional behavior, like
+// authentication, logging, metrics or panic recovery.
+type Middleware func(next http.Handler) http.Handler
+
+// WithMiddleware adds [Middleware] to the handler. The first [Middleware] is
+// the outermost and thus the first to handle a request.
+func WithMiddleware(mw ...Middleware) HandlerOption {
+	return func(h *handler) { h.middleware = append(h.middleware, mw...) }
+}
+
 // HTTPHandler returns a [http.Handler] that writes the health status of the
 // provided [HealthChecker] hc. If hc is a [Checker], the response will be a
 // json object containing the individual statuses of all registered
 // [HealthChecker](s) in hc when health status is not [StatusHealthy].
-func HTTPHandler(hc HealthChecker) http.Handler {
+// Use [HandlerOption](s) to modify the behavior of the handler.
+func HTTPHandler(hc HealthChecker, opts ...HandlerOption) http.Handler {
 	if hc == nil {
 		panic(panicNilHealthChecker)
 	}
-	if checker, ok := hc.(*Checker); ok {
-		return http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
-			stat := checker.CheckHealth(req.Context())
-			wri.WriteHeader(stat.StatusCode())
 
-			if stat == StatusHealthy {
-				_, _ = wri.Write(okBytes)
-			} else {
-				wri.Header().Set("Content-Type", "application/json")
-				_ = json.NewEncoder(wri).Encode(checker.Statuses())
-			}
-		})
+	h := handler{hc: hc}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&h)
+		}
+	}
+
+	var res http.Handler = &h
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		if h.middleware[i] != nil {
+			res = h.middleware[i](res)
+		}
+	}
+	return res
+}
+
+type handler struct {
+	hc         HealthChecker
+	alwaysOK   bool
+	verbose    bool
+	maxTimeout time.Duration
+
+	routesPrefix string
+	historyPath  string
+	corsOrigins  []string
+	corsMethods  string
+	middleware   []Middleware
+	recorder     HandlerRecorder
+}
+
+func (h *handler) ServeHTTP(wri http.ResponseWriter, req *http.Request) {
+	if h.recorder == nil {
+		h.serve(wri, req)
+		return
 	}
 
-	return http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
-		stat := hc.CheckHealth(req.Context())
-		wri.WriteHeader(stat.StatusCode())
+	start := time.Now()
+	sw := statusWriter{ResponseWriter: wri, code: http.StatusOK}
+	route := h.serve(&sw, req)
+	h.recorder.RecordHandle(route, sw.code, time.Since(start))
+}
+
+// serve handles the request and returns the [HandlerRoute] it matched.
+func (h *handler) serve(wri http.ResponseWriter, req *http.Request) HandlerRoute {
+	if len(h.corsOrigins) != 0 && h.writeCORS(wri, req) {
+		return RoutePreflight
+	}
 
-		if stat == StatusHealthy {
-			_, _ = wri.Write(okBytes)
-		} else {
-			_, _ = wri.Write([]byte(stat.String()))
+	ctx := req.Context()
+	if h.maxTimeout > 0 {
+		timeout, err := h.requestTimeout(req)
+		if err != nil {
+			http.Error(wri, err.Error(), http.StatusBadRequest)
+			return RouteHealth
 		}
-	})
+
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, timeout)
+		defer cancelFn()
+	}
+
+	if checker, ok := h.hc.(*Checker); ok && h.historyPath != "" && req.URL.Path == h.historyPath {
+		wri.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(wri).Encode(checker.AuditLog())
+		return RouteHistory
+	}
+	if checker, name := h.checkRoute(req); name != "" {
+		stat, found := checker.CheckHealthOf(ctx, name)
+		if !found {
+			http.NotFound(wri, req)
+			return RouteCheck
+		}
+
+		h.writeStatus(wri, stat, false)
+		return RouteCheck
+	}
+
+	h.writeStatus(wri, h.hc.CheckHealth(ctx), true)
+	return RouteHealth
+}
+
+// checkRoute returns the [Checker] and the name of the [HealthChecker] to check
+// when the request matches a route enabled with [WithCheckRoutes].
+func (h *handler) checkRoute(req *http.Request) (*Checker, string) {
+	if h.routesPrefix == "" {
+		return nil, ""
+	}
+
+	checker, ok := h.hc.(*Checker)
+	if !ok || !strings.HasPrefix(req.URL.Path, h.routesPrefix) {
+		return nil, ""
+	}
+	return checker, strings.TrimPrefix(req.URL.Path, h.routesPrefix)
+}
+
+// writeCORS writes the CORS headers when the request's origin is allowed. It
+// returns true when the request is a preflight request which is handled
+// completely.
+func (h *handler) writeCORS(wri http.ResponseWriter, req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	wri.Header().Add("Vary", "Origin")
+	if origin == "" {
+		return false
+	}
+
+	var allowed bool
+	for _, o := range h.corsOrigins {
+		if o == "*" || o == origin {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return false
+	}
+
+	wri.Header().Set("Access-Control-Allow-Origin", origin)
+	wri.Header().Set("Access-Control-Allow-Methods", h.corsMethods)
+	wri.Header().Set("Access-Control-Expose-Headers", StatusHeader)
+
+	if req.Method != http.MethodOptions || req.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+
+	wri.Header().Set("Access-Control-Allow-Headers", TimeoutHeader)
+	wri.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// requestTimeout returns the timeout requested by the client, capped by
+// the handler's max timeout.
+func (h *handler) requestTimeout(req *http.Request) (time.Duration, error) {
+	val := req.URL.Query().Get(TimeoutQueryParam)
+	if val == "" {
+		val = req.Header.Get(TimeoutHeader)
+	}
+	if val == "" {
+		return h.maxTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(val)
+	if err != nil || timeout <= 0 {
+		return 0, errors.New(ErrInvalidTimeout)
+	}
+	if timeout > h.maxTimeout {
+		return h.maxTimeout, nil
+	}
+	return timeout, nil
+}
+
+func (h *handler) writeStatus(wri http.ResponseWriter, stat Status, details bool) {
+	code := stat.StatusCode()
+	if h.alwaysOK {
+		code = http.StatusOK
+	}
+
+	wri.Header().Set(StatusHeader, stat.String())
+	if checker, ok := h.hc.(*Checker); ok && details && h.verbose {
+		wri.Header().Set("Content-Type", "application/json")
+		wri.WriteHeader(code)
+		_ = json.NewEncoder(wri).Encode(verboseResults(checker.Results()))
+		return
+	}
+	if stat == StatusHealthy {
+		wri.WriteHeader(code)
+		_, _ = wri.Write(okBytes)
+		return
+	}
+
+	if checker, ok := h.hc.(*Checker); ok && details {
+		wri.Header().Set("Content-Type", "application/json")
+		wri.WriteHeader(code)
+		_ = json.NewEncoder(wri).Encode(checker.Statuses())
+		return
+	}
+
+	wri.WriteHeader(code)
+	_, _ = wri.Write([]byte(stat.String()))
+}
+
+type verboseResult struct {
+	Status   Status `json:"status"`
+	Duration string `json:"duration"`
+}
+
+func verboseResults(results map[string]Result) map[string]verboseResult {
+	res := make(map[string]verboseResult, len(results))
+	for name, r := range results {
+		res[name] = verboseResult{
+			Status:   r.Status,
+			Duration: r.Duration.String(),
+		}
+	}
+	return res
 }
@@ -0,0 +1,72 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"net/http"
+	"time"
+)
+
+// HandlerRoute identifies the route of a [http.Handler] created with
+// [HTTPHandler] which handled a request. Its values are suitable to be used as
+// metric labels.
+type HandlerRoute string
+
+const (
+	// RouteHealth is the route which reports the overall health [Status].
+	RouteHealth HandlerRoute = "health"
+	// RouteCheck is a route enabled with [WithCheckRoutes].
+	RouteCheck HandlerRoute = "check"
+	// RouteHistory is the route enabled with [WithHistoryRoute].
+	RouteHistory HandlerRoute = "history"
+	// RoutePreflight is a CORS preflight request, see [WithCORS].
+	RoutePreflight HandlerRoute = "preflight"
+)
+
+// HandlerRecorder records each request handled by a [http.Handler] created
+// with [HTTPHandler]. It can be used to feed metrics systems like Prometheus
+// or OpenTelemetry with request counts, response status codes and latency.
+type HandlerRecorder interface {
+	// RecordHandle is called after each handled request with the
+	// [HandlerRoute] which handled it, the http status code of the response
+	// and the duration of handling the request.
+	RecordHandle(route HandlerRoute, code int, dur time.Duration)
+}
+
+// HandlerRecorderFunc is a func which implements [HandlerRecorder].
+type HandlerRecorderFunc func(route HandlerRoute, code int, dur time.Duration)
+
+func (fn HandlerRecorderFunc) RecordHandle(route HandlerRoute, code int, dur time.Duration) {
+	fn(route, code, dur)
+}
+
+// WithHandlerRecorder sets the [HandlerRecorder] which records each request
+// handled by the handler. Requests rejected by [Middleware] are not
+// recorded.
+func WithHandlerRecorder(r HandlerRecorder) HandlerOption {
+	return func(h *handler) { h.recorder = r }
+}
+
+// statusWriter captures the status code written to a [http.ResponseWriter].
+type statusWriter struct {
+	http.ResponseWriter
+	code        int
+	wroteHeader bool
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.code = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
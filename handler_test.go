@@ -0,0 +1,256 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+			_ = HTTPHandler(nil)
+		})
+	})
+
+	tests := map[string]struct {
+		check    HealthChecker
+		opts     []HandlerOption
+		wantCode int
+		wantBody string
+	}{
+		"healthy": {
+			check:    new(alwaysHealty),
+			wantCode: http.StatusOK,
+			wantBody: "ok",
+		},
+		"unhealthy": {
+			check:    staticStatus(StatusUnhealthy),
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: "unhealthy",
+		},
+		"unhealthy always ok": {
+			check:    staticStatus(StatusUnhealthy),
+			opts:     []HandlerOption{WithAlwaysOK()},
+			wantCode: http.StatusOK,
+			wantBody: "unhealthy",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			HTTPHandler(tc.check, tc.opts...).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PathPattern, nil))
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Equal(t, tc.wantBody, rec.Body.String())
+			assert.Equal(t, tc.check.CheckHealth(context.Background()).String(), rec.Header().Get(StatusHeader))
+		})
+	}
+}
+
+func staticStatus(stat Status) HealthCheckerFunc {
+	return func(context.Context) Status { return stat }
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	const max = 5 * time.Second
+
+	tests := map[string]struct {
+		target   string
+		header   string
+		wantCode int
+		wantMax  time.Duration
+	}{
+		"default":       {target: PathPattern, wantCode: http.StatusOK, wantMax: max},
+		"query":         {target: PathPattern + "?timeout=1s", wantCode: http.StatusOK, wantMax: time.Second},
+		"header":        {target: PathPattern, header: "2s", wantCode: http.StatusOK, wantMax: 2 * time.Second},
+		"capped":        {target: PathPattern + "?timeout=1m", wantCode: http.StatusOK, wantMax: max},
+		"invalid":       {target: PathPattern + "?timeout=soon", wantCode: http.StatusBadRequest},
+		"zero duration": {target: PathPattern + "?timeout=0s", wantCode: http.StatusBadRequest},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var haveDeadline time.Time
+			check := HealthCheckerFunc(func(ctx context.Context) Status {
+				haveDeadline, _ = ctx.Deadline()
+				return StatusHealthy
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if tc.header != "" {
+				req.Header.Set(TimeoutHeader, tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			start := time.Now()
+			HTTPHandler(check, WithRequestTimeout(max)).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantCode == http.StatusOK {
+				assert.WithinDuration(t, start.Add(tc.wantMax), haveDeadline, 100*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestWithCORS(t *testing.T) {
+	const origin = "https://dashboard.example.com"
+
+	tests := map[string]struct {
+		origins    []string
+		method     string
+		preflight  bool
+		wantCode   int
+		wantOrigin string
+	}{
+		"allowed origin": {
+			origins:    []string{origin},
+			method:     http.MethodGet,
+			wantCode:   http.StatusOK,
+			wantOrigin: origin,
+		},
+		"any origin": {
+			origins:    []string{"*"},
+			method:     http.MethodGet,
+			wantCode:   http.StatusOK,
+			wantOrigin: origin,
+		},
+		"disallowed origin": {
+			origins:  []string{"https://other.example.com"},
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+		},
+		"preflight": {
+			origins:    []string{origin},
+			method:     http.MethodOptions,
+			preflight:  true,
+			wantCode:   http.StatusNoContent,
+			wantOrigin: origin,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, PathPattern, nil)
+			req.Header.Set("Origin", origin)
+			if tc.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+
+			rec := httptest.NewRecorder()
+			HTTPHandler(new(alwaysHealty), WithCORS(tc.origins)).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			assert.Equal(t, tc.wantOrigin, rec.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestWithMiddleware(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(wri, req)
+			})
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	HTTPHandler(new(alwaysHealty), WithMiddleware(mw("first"), nil, mw("second"))).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PathPattern, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+func TestWithCheckRoutes(t *testing.T) {
+	checker, err := New(
+		WithHealthChecker("db", new(alwaysHealty)),
+		WithHealthChecker("cache", staticStatus(StatusUnhealthy)),
+	)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		target   string
+		wantCode int
+	}{
+		"all":     {target: PathPattern, wantCode: http.StatusServiceUnavailable},
+		"healthy": {target: PathPattern + "/db", wantCode: http.StatusOK},
+		"failing": {target: PathPattern + "/cache", wantCode: http.StatusServiceUnavailable},
+		"unknown": {target: PathPattern + "/queue", wantCode: http.StatusNotFound},
+	}
+
+	h := HTTPHandler(checker, WithCheckRoutes(PathPattern))
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+			assert.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
+
+func TestWithVerbose(t *testing.T) {
+	checker, err := New(
+		WithHealthChecker("db", new(alwaysHealty)),
+		WithHealthChecker("slow", HealthCheckerFunc(func(context.Context) Status {
+			time.Sleep(5 * time.Millisecond)
+			return StatusHealthy
+		})),
+	)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	HTTPHandler(checker, WithVerbose()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PathPattern, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var have map[string]verboseResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &have))
+	assert.Len(t, have, 2)
+	assert.Equal(t, StatusHealthy, have["slow"].Status)
+
+	dur, err := time.ParseDuration(have["slow"].Duration)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, dur, 5*time.Millisecond)
+	assert.Equal(t, checker.Results()["slow"].Duration.String(), have["slow"].Duration)
+}
+
+func TestWithHandlerRecorder(t *testing.T) {
+	checker, err := New(WithHealthChecker("db", staticStatus(StatusUnhealthy)))
+	assert.NoError(t, err)
+
+	type record struct {
+		route HandlerRoute
+		code  int
+	}
+
+	var have []record
+	h := HTTPHandler(checker,
+		WithCheckRoutes(PathPattern),
+		WithHandlerRecorder(HandlerRecorderFunc(func(route HandlerRoute, code int, dur time.Duration) {
+			assert.Greater(t, dur, time.Duration(0))
+			have = append(have, record{route, code})
+		})),
+	)
+
+	for _, target := range []string{PathPattern, PathPattern + "/db", PathPattern + "/queue"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	assert.Equal(t, []record{
+		{RouteHealth, http.StatusServiceUnavailable},
+		{RouteCheck, http.StatusServiceUnavailable},
+		{RouteCheck, http.StatusNotFound},
+	}, have)
+}
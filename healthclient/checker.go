@@ -0,0 +1,45 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+
+	"github.com/go-pogo/healthcheck"
+)
+
+var _ healthcheck.HealthChecker = (*HealthChecker)(nil)
+
+// HealthChecker is a [healthcheck.HealthChecker] which performs a health
+// check request to the target of a [Client].
+type HealthChecker struct {
+	client *Client
+}
+
+// AsHealthChecker returns a [HealthChecker] which performs a health check
+// request to the target of c. This allows a [healthcheck.Checker] to include
+// the health of an upstream service. The error of a failed request is
+// available to the [healthcheck.Checker], e.g. in the
+// [healthcheck.Result] of the check, via [HealthChecker.Check].
+//
+//	checker.Register("upstream", healthclient.AsHealthChecker(client))
+func AsHealthChecker(c *Client) *HealthChecker {
+	if c == nil {
+		panic(panicNilClient)
+	}
+	return &HealthChecker{client: c}
+}
+
+// CheckHealth performs a health check request, see [HealthChecker.Check].
+func (hc *HealthChecker) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := hc.Check(ctx)
+	return stat
+}
+
+// Check performs a health check request to the target and returns its
+// [healthcheck.Status] and any error, see [Client.Request].
+func (hc *HealthChecker) Check(ctx context.Context) (healthcheck.Status, error) {
+	return hc.client.Request(ctx)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsHealthChecker(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilClient, func() {
+			_ = AsHealthChecker(nil)
+		})
+	})
+
+	tests := map[string]struct {
+		code    int
+		want    healthcheck.Status
+		wantErr any
+	}{
+		"healthy":   {code: http.StatusOK, want: healthcheck.StatusHealthy},
+		"unhealthy": {code: http.StatusServiceUnavailable, want: healthcheck.StatusUnhealthy},
+		"invalid": {
+			code:    http.StatusTeapot,
+			want:    healthcheck.StatusUnknown,
+			wantErr: new(*InvalidStatusCode),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+				wri.WriteHeader(tc.code)
+			}))
+			defer srv.Close()
+
+			client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+			assert.NoError(t, err)
+
+			checker, err := healthcheck.New(healthcheck.WithHealthChecker("upstream", AsHealthChecker(client)))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, checker.CheckHealth(context.Background()))
+
+			res := checker.Results()["upstream"]
+			if tc.wantErr == nil {
+				assert.NoError(t, res.Err)
+			} else {
+				assert.ErrorAs(t, res.Err, tc.wantErr)
+			}
+		})
+	}
+}
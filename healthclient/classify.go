@@ -0,0 +1,117 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrTimeout           errors.Msg = "timeout"
+	ErrHostNotFound      errors.Msg = "host not found"
+	ErrConnectionRefused errors.Msg = "connection refused"
+	ErrTLSHandshake      errors.Msg = "tls handshake failed"
+)
+
+// ErrorStatusMapper maps the error of a failed health check request to a
+// [healthcheck.Status]. The error wraps [ErrRequestFailed] and, when
+// recognized, one of [ErrTimeout], [ErrHostNotFound], [ErrConnectionRefused]
+// or [ErrTLSHandshake].
+type ErrorStatusMapper func(err error) healthcheck.Status
+
+// DefaultErrorStatusMapper is the [ErrorStatusMapper] used by [Client] when
+// no other [ErrorStatusMapper] is set. A target which cannot be found, refuses
+// the connection or fails the tls handshake is reported as
+// [healthcheck.StatusUnhealthy]. Any other error, like a timeout, is reported
+// as [healthcheck.StatusUnknown].
+func DefaultErrorStatusMapper(err error) healthcheck.Status {
+	switch {
+	case errors.Is(err, ErrHostNotFound),
+		errors.Is(err, ErrConnectionRefused),
+		errors.Is(err, ErrTLSHandshake):
+		return healthcheck.StatusUnhealthy
+	default:
+		return healthcheck.StatusUnknown
+	}
+}
+
+// WithErrorStatusMapper sets the [ErrorStatusMapper] which is used to map the
+// errors of failed health check requests to a [healthcheck.Status].
+func WithErrorStatusMapper(fn ErrorStatusMapper) Option {
+	return func(c *Client) error {
+		c.errorStatusMapper = fn
+		return nil
+	}
+}
+
+// requestFailed wraps err with [ErrRequestFailed] and, when recognized, the
+// kind of failure.
+func requestFailed(err error) error {
+	if kind := classifyRequestError(err); kind != "" && !errors.Is(err, kind) {
+		err = errors.Wrap(err, kind)
+	}
+	return errors.Wrap(err, ErrRequestFailed)
+}
+
+// classifyRequestError returns the kind of failure of err, which is either
+// one of [ErrTimeout], [ErrHostNotFound], [ErrConnectionRefused] or
+// [ErrTLSHandshake], or an empty [errors.Msg] when not recognized. It is the
+// single place where request errors are classified and is used by both
+// [requestFailed] and [ClassifyError].
+func classifyRequestError(err error) errors.Msg {
+	var netErr net.Error
+	if errors.Is(err, ErrTimeout) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrTimeout
+	}
+
+	var dnsErr *net.DNSError
+	if errors.Is(err, ErrHostNotFound) || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		return ErrHostNotFound
+	}
+	if errors.Is(err, ErrConnectionRefused) || errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrConnectionRefused
+	}
+	if errors.Is(err, ErrTLSHandshake) || isTLSError(err) {
+		return ErrTLSHandshake
+	}
+	return ""
+}
+
+func isTLSError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	switch {
+	case errors.As(err, &verifyErr),
+		errors.As(err, &recordErr),
+		errors.As(err, &authorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &invalidErr):
+		return true
+	default:
+		// alerts sent by the remote are of an unexported type
+		return strings.Contains(err.Error(), "remote error: tls: ")
+	}
+}
+
+func (c *Client) errorStatus(err error) healthcheck.Status {
+	if c.errorStatusMapper != nil {
+		return c.errorStatusMapper(err)
+	}
+	return DefaultErrorStatusMapper(err)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestFailed(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		wantKind   error
+		wantStatus healthcheck.Status
+	}{
+		"timeout": {
+			err:        &url.Error{Op: "Get", Err: context.DeadlineExceeded},
+			wantKind:   ErrTimeout,
+			wantStatus: healthcheck.StatusUnknown,
+		},
+		"host not found": {
+			err:        &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", IsNotFound: true}},
+			wantKind:   ErrHostNotFound,
+			wantStatus: healthcheck.StatusUnhealthy,
+		},
+		"connection refused": {
+			err:        &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED},
+			wantKind:   ErrConnectionRefused,
+			wantStatus: healthcheck.StatusUnhealthy,
+		},
+		"tls handshake": {
+			err:        &url.Error{Op: "Get", Err: x509.UnknownAuthorityError{}},
+			wantKind:   ErrTLSHandshake,
+			wantStatus: healthcheck.StatusUnhealthy,
+		},
+		"other": {
+			err:        errors.New("some error"),
+			wantKind:   ErrRequestFailed,
+			wantStatus: healthcheck.StatusUnknown,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := requestFailed(tc.err)
+			assert.ErrorIs(t, err, ErrRequestFailed)
+			assert.ErrorIs(t, err, tc.wantKind)
+			assert.Equal(t, tc.wantStatus, DefaultErrorStatusMapper(err))
+		})
+	}
+}
+
+func TestWithErrorStatusMapper(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	url := srv.URL
+	srv.Close()
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&url),
+		WithErrorStatusMapper(func(error) healthcheck.Status {
+			return healthcheck.StatusUnknown
+		}),
+	)
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.Equal(t, healthcheck.StatusUnknown, stat)
+	assert.ErrorIs(t, err, ErrConnectionRefused)
+}
+
+func TestClient_Request_refused(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&url))
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+	assert.ErrorIs(t, err, ErrConnectionRefused)
+}
@@ -5,13 +5,19 @@
 package healthclient
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"io"
+	"mime"
 	"net"
 	"net/http"
 	urlpkg "net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-pogo/errors"
@@ -19,8 +25,13 @@ import (
 )
 
 const (
-	ErrInvalidBaseURL errors.Msg = "invalid bound base url"
-	ErrRequestFailed  errors.Msg = "request failed"
+	ErrInvalidBaseURL   errors.Msg = "invalid bound base url"
+	ErrInvalidTargetURL errors.Msg = "invalid target url"
+	ErrRequestFailed    errors.Msg = "request failed"
+	ErrInvalidReport    errors.Msg = "invalid report"
+
+	ErrMaxLatencyExceeded errors.Msg = "max latency exceeded"
+	ErrUnexpectedBody     errors.Msg = "unexpected response body"
 )
 
 // InvalidStatusCode contains the non-expected status code received from a
@@ -33,14 +44,69 @@ func (e InvalidStatusCode) Error() string {
 	return "invalid status code " + strconv.Itoa(e.Code)
 }
 
+// UnhealthyError is returned when the target responds with
+// [healthcheck.StatusUnhealthy] together with the json details of its
+// individual health checks, as written by [healthcheck.HTTPHandler].
+type UnhealthyError struct {
+	// Checks contains the statuses of the target's individual health checks.
+	Checks map[string]healthcheck.Status
+}
+
+// Failed returns the sorted names of the target's health checks which are not
+// [healthcheck.StatusHealthy].
+func (e *UnhealthyError) Failed() []string {
+	res := make([]string, 0, len(e.Checks))
+	for name, stat := range e.Checks {
+		if stat != healthcheck.StatusHealthy {
+			res = append(res, name)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (e *UnhealthyError) Error() string {
+	failed := e.Failed()
+	if len(failed) == 0 {
+		return "target is unhealthy"
+	}
+	return "target is unhealthy, failed checks: " + strings.Join(failed, ", ")
+}
+
 // Client is a simple http.Client which can be used to perform health checks
 // on a target (web)service.
 type Client struct {
 	Config
 
 	httpClient        *http.Client
+	dialer            *net.Dialer
+	customDial        bool
+	targetURL         *urlpkg.URL
+	scheme            string
 	bindTargetBaseURL *string
 	bindTargetPath    *string
+	retryAttempts     int
+	retryBackoff      time.Duration
+	statusCodeMapper  StatusCodeMapper
+	errorStatusMapper ErrorStatusMapper
+	method            string
+	header            http.Header
+	targetResolver    TargetResolver
+	maxLatency        time.Duration
+	bodyMatcher       func(body []byte) bool
+	recorder          Recorder
+	log               Logger
+	warnInsecure      bool
+	contextHeaders    []ContextHeadersFunc
+	onRequest         []RequestHook
+	onResponse        []ResponseHook
+	minInterval       time.Duration
+	cache             struct {
+		mut  sync.Mutex
+		stat healthcheck.Status
+		err  error
+		time time.Time
+	}
 }
 
 func New(conf Config, opts ...Option) (*Client, error) {
@@ -56,10 +122,25 @@ func (c *Client) With(opts ...Option) error {
 		}
 		err = errors.Append(err, opt(c))
 	}
+	if c.warnInsecure && c.log != nil {
+		c.log.LogWarning("tls certificate verification is disabled, connections are insecure")
+		c.warnInsecure = false
+	}
 	return err
 }
 
+// TargetURL returns the url of the target's health check endpoint. When set,
+// the url from [WithTargetURL] is returned as is. Otherwise, the url is based
+// on the [Config] and the values bound with [WithBindTargetBaseURL] and
+// [WithBindTargetPath]. The target path may contain a query string. The scheme
+// is "https" when a [tls.Config] is set, unless set otherwise with
+// [WithScheme].
 func (c *Client) TargetURL() (*urlpkg.URL, error) {
+	if c.targetURL != nil {
+		url := *c.targetURL
+		return &url, nil
+	}
+
 	var url *urlpkg.URL
 	var err error
 
@@ -87,13 +168,21 @@ func (c *Client) TargetURL() (*urlpkg.URL, error) {
 		}
 	}
 
-	if c.TLSConfig() != nil {
+	if c.scheme != "" {
+		url.Scheme = c.scheme
+	} else if c.TLSConfig() != nil {
 		url.Scheme = "https"
 	}
+
+	path := c.TargetPath
 	if c.bindTargetPath != nil {
-		url.Path = *c.bindTargetPath
+		path = *c.bindTargetPath
+	}
+	if path, query, ok := strings.Cut(path, "?"); ok {
+		url.Path = path
+		url.RawQuery = query
 	} else {
-		url.Path = c.TargetPath
+		url.Path = path
 	}
 
 	return url, nil
@@ -109,53 +198,268 @@ func (c *Client) TLSConfig() *tls.Config {
 	return nil
 }
 
+// Request performs a health check request to the target and returns the
+// [healthcheck.Status] based on the response's status code.
+// When a minimum interval is set using [WithMinInterval], the cached result of
+// the previous request is returned for requests within this interval.
 func (c *Client) Request(ctx context.Context) (healthcheck.Status, error) {
-	timeout := c.Config.RequestTimeout
-	if timeout == 0 {
-		timeout = 3 * time.Second
+	if c.minInterval <= 0 {
+		stat, _, err := c.request(ctx, false)
+		return stat, err
+	}
+
+	c.cache.mut.Lock()
+	defer c.cache.mut.Unlock()
+
+	if !c.cache.time.IsZero() && time.Since(c.cache.time) < c.minInterval {
+		return c.cache.stat, c.cache.err
+	}
+
+	stat, _, err := c.request(ctx, false)
+	c.cache.stat, c.cache.err, c.cache.time = stat, err, time.Now()
+	return stat, err
+}
+
+// RequestReport performs a health check request to the target, like
+// [Client.Request], and parses the statuses of the target's individual health
+// checks from the response body when it contains the json details written by
+// [healthcheck.HTTPHandler].
+func (c *Client) RequestReport(ctx context.Context) (*healthcheck.Report, error) {
+	stat, resp, err := c.request(ctx, true)
+	var unhealthyErr *UnhealthyError
+	if errors.As(err, &unhealthyErr) {
+		return &healthcheck.Report{Status: stat, Checks: unhealthyErr.Checks}, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &healthcheck.Report{Status: stat}
+	if len(resp.body) != 0 && isJSON(resp.Header) {
+		if rep.Checks, err = decodeChecks(resp.body); err != nil {
+			return rep, errors.Wrap(err, ErrInvalidReport)
+		}
+	}
+	return rep, nil
+}
+
+// maxBodySize is the maximum number of bytes read from a response body.
+const maxBodySize = 1 << 20
+
+type response struct {
+	*http.Response
+	body    []byte
+	latency time.Duration
+}
+
+func (c *Client) request(ctx context.Context, readBody bool) (healthcheck.Status, *response, error) {
+	url, err := c.TargetURL()
+	if err != nil {
+		return healthcheck.StatusUnknown, nil, errors.Wrap(err, ErrRequestFailed)
+	}
+	return c.requestURL(ctx, url, "", readBody)
+}
+
+// requestURL performs a health check request to url. When addr is not empty,
+// the connection is made to addr instead of the host of url, while the Host
+// header and TLS server name remain those of url.
+func (c *Client) requestURL(ctx context.Context, url *urlpkg.URL, addr string, readBody bool) (stat healthcheck.Status, resp *response, err error) {
+	target := url.Host
+	if addr != "" {
+		target = addr
+	}
+	if c.recorder != nil || c.log != nil {
+		start := time.Now()
+		defer func() {
+			dur := time.Since(start)
+			if c.recorder != nil {
+				c.recorder.RecordRequest(target, dur, stat, ClassifyError(err))
+			}
+			if c.log != nil {
+				c.log.LogRequest(target, dur, stat, err)
+			}
+		}()
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if addr != "" {
+		var closeFn func()
+		if httpClient, closeFn, err = pinnedClient(httpClient, addr); err != nil {
+			return healthcheck.StatusUnknown, nil, errors.Wrap(err, ErrRequestFailed)
+		}
+		defer closeFn()
+	}
+
+	timeout := c.Config.timeout()
 	if t, ok := ctx.Deadline(); !ok || timeout < time.Until(t) {
 		var cancelFn context.CancelFunc
 		ctx, cancelFn = context.WithTimeout(ctx, timeout)
 		defer cancelFn()
 	}
 
-	url, err := c.TargetURL()
-	if err != nil {
-		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	method := c.method
+	if method == "" {
+		method = http.MethodGet
 	}
 
 	req := &http.Request{
-		Method:     http.MethodGet,
+		Method:     method,
 		URL:        url,
 		Proto:      "HTTP/1.1",
 		ProtoMajor: 1,
 		ProtoMinor: 1,
-		Header:     make(http.Header),
+		Header:     c.header.Clone(),
 		Host:       url.Host,
 	}
-
-	if c.httpClient == nil {
-		c.httpClient = http.DefaultClient
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	} else if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+		req.Header.Del("Host")
+	}
+	for _, fn := range c.contextHeaders {
+		fn(ctx, req.Header)
+	}
+	for _, fn := range c.onRequest {
+		fn(req)
 	}
 
-	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	start := time.Now()
+	httpResp, latency, err := c.do(ctx, httpClient, req)
+	if len(c.onResponse) != 0 {
+		dur := time.Since(start)
+		for _, fn := range c.onResponse {
+			fn(req, httpResp, dur, err)
+		}
+	}
 	if err != nil {
-		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+		err = requestFailed(err)
+		return c.errorStatus(err), nil, err
 	}
 
-	_ = resp.Body.Close()
+	resp = &response{Response: httpResp, latency: latency}
+	if readBody || c.bodyMatcher != nil || isJSON(httpResp.Header) {
+		resp.body, err = io.ReadAll(io.LimitReader(httpResp.Body, maxBodySize))
+	}
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return healthcheck.StatusUnknown, resp, errors.Wrap(err, ErrRequestFailed)
+	}
 
-	switch resp.StatusCode {
-	case http.StatusTooEarly:
-		return healthcheck.StatusUnknown, nil
-	case http.StatusOK, http.StatusNoContent:
-		return healthcheck.StatusHealthy, nil
-	case http.StatusServiceUnavailable:
-		return healthcheck.StatusUnhealthy, nil
-	default:
-		return healthcheck.StatusUnknown, errors.WithStack(&InvalidStatusCode{
+	mapper := c.statusCodeMapper
+	if mapper == nil {
+		mapper = DefaultStatusCodeMapper
+	}
+	stat, ok := mapper(resp.StatusCode)
+	if !ok {
+		return healthcheck.StatusUnknown, resp, errors.WithStack(&InvalidStatusCode{
 			Code: resp.StatusCode,
 		})
 	}
+	if stat == healthcheck.StatusUnhealthy && len(resp.body) != 0 && isJSON(resp.Header) {
+		if checks, err := decodeChecks(resp.body); err == nil {
+			return stat, resp, errors.WithStack(&UnhealthyError{Checks: checks})
+		}
+	}
+	if stat != healthcheck.StatusHealthy {
+		return stat, resp, nil
+	}
+	if c.bodyMatcher != nil && !c.bodyMatcher(resp.body) {
+		return healthcheck.StatusUnknown, resp, errors.New(ErrUnexpectedBody)
+	}
+	if c.maxLatency > 0 && resp.latency > c.maxLatency {
+		return healthcheck.StatusUnknown, resp, errors.New(ErrMaxLatencyExceeded)
+	}
+	return stat, resp, nil
+}
+
+// decodeChecks decodes the json details of the individual health checks as
+// written by [healthcheck.HTTPHandler]. These are either a map of statuses,
+// or a map of objects containing a status when [healthcheck.WithVerbose] is
+// used.
+func decodeChecks(body []byte) (map[string]healthcheck.Status, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]healthcheck.Status, len(raw))
+	for name, val := range raw {
+		if val = bytes.TrimSpace(val); len(val) != 0 && val[0] == '{' {
+			var v struct {
+				Status healthcheck.Status `json:"status"`
+			}
+			if err := json.Unmarshal(val, &v); err != nil {
+				return nil, err
+			}
+			res[name] = v.Status
+			continue
+		}
+
+		var stat healthcheck.Status
+		if err := json.Unmarshal(val, &stat); err != nil {
+			return nil, err
+		}
+		res[name] = stat
+	}
+	return res, nil
+}
+
+func isJSON(h http.Header) bool {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	return mediaType == "application/json"
+}
+
+// do sends the request and retries it when it fails, according to the
+// settings of [WithRetry]. It returns the latency of the last attempt, which
+// excludes the duration of earlier attempts and backoff delays.
+func (c *Client) do(ctx context.Context, httpClient *http.Client, req *http.Request) (*http.Response, time.Duration, error) {
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err := c.doAttempt(ctx, httpClient, req)
+		if err == nil || attempt >= c.retryAttempts {
+			return resp, time.Since(start), err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, err
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// doAttempt sends the request once, bound by the [Config.AttemptTimeout].
+func (c *Client) doAttempt(ctx context.Context, httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	if c.Config.AttemptTimeout <= 0 {
+		return httpClient.Do(req.WithContext(ctx))
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, c.Config.AttemptTimeout)
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancelFn()
+		return nil, err
+	}
+
+	// the attempt's context must remain valid until the body is closed
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancelFn: cancelFn}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancelFn context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	defer b.cancelFn()
+	return b.ReadCloser.Close()
 }
@@ -8,9 +8,14 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/go-pogo/easytls"
 	"github.com/go-pogo/healthcheck"
@@ -38,6 +43,25 @@ func TestClient_TargetURL(t *testing.T) {
 				Host:   "localhost:1234",
 			},
 		},
+		"target path with query": {
+			conf: Config{TargetPath: "/healthz?verbose=1"},
+			wantURL: url.URL{
+				Scheme:   "http",
+				Host:     "localhost",
+				Path:     "/healthz",
+				RawQuery: "verbose=1",
+			},
+		},
+		"target url": {
+			conf: Config{TargetHostname: "ignored", TargetPath: "/ignored"},
+			opts: []Option{WithTargetURL("https://example.com:8443/health?full=true")},
+			wantURL: url.URL{
+				Scheme:   "https",
+				Host:     "example.com:8443",
+				Path:     "/health",
+				RawQuery: "full=true",
+			},
+		},
 		"target port with tls": {
 			conf: Config{TargetPort: 1234},
 			opts: []Option{WithTLSConfig(&tls.Config{})},
@@ -46,6 +70,22 @@ func TestClient_TargetURL(t *testing.T) {
 				Host:   "localhost:1234",
 			},
 		},
+		"http scheme with tls": {
+			conf: Config{TargetPort: 1234},
+			opts: []Option{WithTLSConfig(&tls.Config{}), WithScheme("http")},
+			wantURL: url.URL{
+				Scheme: "http",
+				Host:   "localhost:1234",
+			},
+		},
+		"https scheme without tls": {
+			conf: Config{TargetPort: 1234},
+			opts: []Option{WithScheme("HTTPS")},
+			wantURL: url.URL{
+				Scheme: "https",
+				Host:   "localhost:1234",
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -63,6 +103,20 @@ func TestClient_TargetURL(t *testing.T) {
 	}
 }
 
+func TestWithTargetURL(t *testing.T) {
+	for _, rawURL := range []string{"/healthy", "localhost:8080", "://"} {
+		t.Run(rawURL, func(t *testing.T) {
+			_, err := New(Config{}, WithTargetURL(rawURL))
+			assert.ErrorIs(t, err, ErrInvalidTargetURL)
+		})
+	}
+}
+
+func TestWithScheme(t *testing.T) {
+	_, err := New(Config{}, WithScheme("ftp"))
+	assert.ErrorIs(t, err, ErrInvalidScheme)
+}
+
 func TestClient_Request(t *testing.T) {
 	t.Run("without tls", func(t *testing.T) {
 		srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
@@ -96,3 +150,446 @@ func TestClient_Request(t *testing.T) {
 		assert.Equal(t, healthcheck.StatusHealthy, stat)
 	})
 }
+
+func TestWithRetry(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	tests := map[string]struct {
+		failures   int32
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"success after retries": {
+			failures:   2,
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"failure": {
+			failures:   3,
+			wantStatus: healthcheck.StatusUnhealthy,
+			wantErr:    ErrConnectionRefused,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var count atomic.Int32
+			transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if count.Add(1) <= tc.failures {
+					return nil, syscall.ECONNREFUSED
+				}
+				return http.DefaultTransport.RoundTrip(req)
+			})
+
+			client, err := New(Config{},
+				WithBindTargetBaseURL(&srv.URL),
+				WithHTTPClient(&http.Client{Transport: transport}),
+				WithRetry(3, time.Millisecond),
+			)
+			assert.NoError(t, err)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+			assert.Equal(t, int32(3), count.Load())
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return fn(req) }
+
+func TestClient_RequestReport(t *testing.T) {
+	healthy := healthcheck.HealthCheckerFunc(func(context.Context) healthcheck.Status {
+		return healthcheck.StatusHealthy
+	})
+	unhealthy := healthcheck.HealthCheckerFunc(func(context.Context) healthcheck.Status {
+		return healthcheck.StatusUnhealthy
+	})
+
+	tests := map[string]struct {
+		checks     map[string]healthcheck.HealthChecker
+		opts       []healthcheck.HandlerOption
+		wantReport *healthcheck.Report
+	}{
+		"unhealthy": {
+			checks: map[string]healthcheck.HealthChecker{"db": healthy, "cache": unhealthy},
+			wantReport: &healthcheck.Report{
+				Status: healthcheck.StatusUnhealthy,
+				Checks: map[string]healthcheck.Status{
+					"db":    healthcheck.StatusHealthy,
+					"cache": healthcheck.StatusUnhealthy,
+				},
+			},
+		},
+		"verbose unhealthy": {
+			checks: map[string]healthcheck.HealthChecker{"db": healthy, "cache": unhealthy},
+			opts:   []healthcheck.HandlerOption{healthcheck.WithVerbose()},
+			wantReport: &healthcheck.Report{
+				Status: healthcheck.StatusUnhealthy,
+				Checks: map[string]healthcheck.Status{
+					"db":    healthcheck.StatusHealthy,
+					"cache": healthcheck.StatusUnhealthy,
+				},
+			},
+		},
+		"verbose healthy": {
+			checks: map[string]healthcheck.HealthChecker{"db": healthy},
+			opts:   []healthcheck.HandlerOption{healthcheck.WithVerbose()},
+			wantReport: &healthcheck.Report{
+				Status: healthcheck.StatusHealthy,
+				Checks: map[string]healthcheck.Status{"db": healthcheck.StatusHealthy},
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			checker, err := healthcheck.New()
+			assert.NoError(t, err)
+			for name, check := range tc.checks {
+				checker.Register(name, check)
+			}
+
+			srv := httptest.NewServer(healthcheck.HTTPHandler(checker, tc.opts...))
+			defer srv.Close()
+
+			client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+			assert.NoError(t, err)
+
+			rep, err := client.RequestReport(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantReport, rep)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantReport.Status, stat)
+			if stat == healthcheck.StatusHealthy {
+				assert.NoError(t, err)
+				return
+			}
+
+			var unhealthyErr *UnhealthyError
+			assert.ErrorAs(t, err, &unhealthyErr)
+			assert.Equal(t, tc.wantReport.Checks, unhealthyErr.Checks)
+		})
+	}
+}
+
+func TestUnhealthyError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.Header().Set("Content-Type", "application/json")
+		wri.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(wri).Encode(map[string]healthcheck.Status{
+			"db":    healthcheck.StatusUnhealthy,
+			"cache": healthcheck.StatusHealthy,
+			"queue": healthcheck.StatusUnknown,
+		})
+	}))
+	defer srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+
+	var unhealthyErr *UnhealthyError
+	if assert.ErrorAs(t, err, &unhealthyErr) {
+		assert.Equal(t, []string{"db", "queue"}, unhealthyErr.Failed())
+		assert.Equal(t, "target is unhealthy, failed checks: db, queue", unhealthyErr.Error())
+	}
+}
+
+func TestWithMethod(t *testing.T) {
+	var haveMethod string
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveMethod = req.Method
+	}))
+	defer srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithMethod(http.MethodHead))
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.Equal(t, http.MethodHead, haveMethod)
+}
+
+func TestWithHeader(t *testing.T) {
+	var haveReq *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveReq = req
+	}))
+	defer srv.Close()
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&srv.URL),
+		WithHeader("X-Api-Version", "2"),
+		WithHeader("Host", "example.com"),
+		WithUserAgent("healthclient-test"),
+	)
+	assert.NoError(t, err)
+
+	_, err = client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "2", haveReq.Header.Get("X-Api-Version"))
+	assert.Equal(t, "healthclient-test", haveReq.UserAgent())
+	assert.Equal(t, "example.com", haveReq.Host)
+}
+
+func TestWithAuthorization(t *testing.T) {
+	var haveReq *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveReq = req
+	}))
+	defer srv.Close()
+
+	t.Run("bearer", func(t *testing.T) {
+		client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithBearerToken("secret"))
+		assert.NoError(t, err)
+
+		_, err = client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer secret", haveReq.Header.Get("Authorization"))
+	})
+	t.Run("basic", func(t *testing.T) {
+		client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithBasicAuth("user", "pass"))
+		assert.NoError(t, err)
+
+		_, err = client.Request(context.Background())
+		assert.NoError(t, err)
+
+		user, pass, ok := haveReq.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+	})
+}
+
+func TestWithMaxLatency(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer srv.Close()
+
+	tests := map[string]struct {
+		path       string
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"fast": {path: "/fast", wantStatus: healthcheck.StatusHealthy},
+		"slow": {path: "/slow", wantStatus: healthcheck.StatusUnknown, wantErr: ErrMaxLatencyExceeded},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := New(Config{},
+				WithBindTargetBaseURL(&srv.URL),
+				WithBindTargetPath(&tc.path),
+				WithMaxLatency(50*time.Millisecond),
+			)
+			assert.NoError(t, err)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("retry", func(t *testing.T) {
+		var count atomic.Int32
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if count.Add(1) == 1 {
+				return nil, syscall.ECONNREFUSED
+			}
+			return http.DefaultTransport.RoundTrip(req)
+		})
+
+		path := "/fast"
+		client, err := New(Config{},
+			WithBindTargetBaseURL(&srv.URL),
+			WithBindTargetPath(&path),
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithRetry(2, 100*time.Millisecond),
+			WithMaxLatency(50*time.Millisecond),
+		)
+		assert.NoError(t, err)
+
+		stat, err := client.Request(context.Background())
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.NoError(t, err, "backoff delay should not count as latency")
+		assert.Equal(t, int32(2), count.Load())
+	})
+}
+
+func TestWithExpectBody(t *testing.T) {
+	healthy := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer healthy.Close()
+	portal := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		_, _ = wri.Write([]byte("<html>please login</html>"))
+	}))
+	defer portal.Close()
+
+	tests := map[string]struct {
+		url        string
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"expected": {url: healthy.URL, wantStatus: healthcheck.StatusHealthy},
+		"portal":   {url: portal.URL, wantStatus: healthcheck.StatusUnknown, wantErr: ErrUnexpectedBody},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := New(Config{}, WithBindTargetBaseURL(&tc.url), WithExpectBody("ok"))
+			assert.NoError(t, err)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedirectOptions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/healthy", healthcheck.SimpleHTTPHandler())
+	mux.Handle("/redirect1", http.RedirectHandler("/healthy", http.StatusFound))
+	mux.Handle("/redirect2", http.RedirectHandler("/redirect1", http.StatusFound))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := map[string]struct {
+		path       string
+		opts       []Option
+		wantStatus healthcheck.Status
+		wantErr    error
+	}{
+		"follow": {
+			path:       "/redirect2",
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"no follow": {
+			path:       "/redirect1",
+			opts:       []Option{WithNoFollowRedirects()},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    &InvalidStatusCode{Code: http.StatusFound},
+		},
+		"within max": {
+			path:       "/redirect1",
+			opts:       []Option{WithMaxRedirects(1)},
+			wantStatus: healthcheck.StatusHealthy,
+		},
+		"exceeds max": {
+			path:       "/redirect2",
+			opts:       []Option{WithMaxRedirects(1)},
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    ErrTooManyRedirects,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := New(Config{}, append(tc.opts,
+				WithBindTargetBaseURL(&srv.URL),
+				WithBindTargetPath(&tc.path),
+			)...)
+			assert.NoError(t, err)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else if codeErr, ok := tc.wantErr.(*InvalidStatusCode); ok {
+				var haveErr *InvalidStatusCode
+				assert.ErrorAs(t, err, &haveErr)
+				assert.Equal(t, codeErr, haveErr)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedirectOptions_copy(t *testing.T) {
+	opts := map[string]Option{
+		"no follow":     WithNoFollowRedirects(),
+		"max redirects": WithMaxRedirects(1),
+	}
+	for name, opt := range opts {
+		t.Run(name, func(t *testing.T) {
+			var httpClient http.Client
+			client, err := New(Config{}, WithHTTPClient(&httpClient), opt)
+			assert.NoError(t, err)
+			assert.NotNil(t, client.httpClient.CheckRedirect)
+			assert.Nil(t, httpClient.CheckRedirect)
+		})
+		t.Run(name+" default client", func(t *testing.T) {
+			client, err := New(Config{}, opt)
+			assert.NoError(t, err)
+			assert.NotNil(t, client.httpClient.CheckRedirect)
+			assert.NotSame(t, http.DefaultClient, client.httpClient)
+			assert.Nil(t, http.DefaultClient.CheckRedirect)
+		})
+	}
+}
+
+func TestConfig_AttemptTimeout(t *testing.T) {
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		if count.Add(1) == 1 {
+			// first attempt hangs until it times out
+			<-req.Context().Done()
+			return
+		}
+		_, _ = wri.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client, err := New(
+		Config{OverallTimeout: time.Second, AttemptTimeout: 50 * time.Millisecond},
+		WithBindTargetBaseURL(&srv.URL),
+		WithRetry(2, time.Millisecond),
+		WithExpectBody("ok"),
+	)
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.Equal(t, int32(2), count.Load())
+}
+
+func TestWithMinInterval(t *testing.T) {
+	var count atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		count.Add(1)
+	}))
+	defer srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithMinInterval(50*time.Millisecond))
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		stat, err := client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+	}
+	assert.Equal(t, int32(1), count.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	_, _ = client.Request(context.Background())
+	assert.Equal(t, int32(2), count.Load())
+}
@@ -5,7 +5,20 @@
 package healthclient
 
 import (
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
+
+	"github.com/go-pogo/errors"
+)
+
+const (
+	ErrInvalidConfig errors.Msg = "invalid config"
+	ErrInvalidEnv    errors.Msg = "invalid environment variable"
 )
 
 type Config struct {
@@ -15,7 +28,17 @@ type Config struct {
 	TargetPort uint16 `env:"" default:"8080"`
 	// TargetPath is the path to the health check endpoint on the target server.
 	TargetPath string `env:"" default:"/healthy"`
+	// OverallTimeout is the maximum time to wait for a health check response,
+	// including any retries. When zero, RequestTimeout is used.
+	OverallTimeout time.Duration `env:""`
+	// AttemptTimeout is the maximum time to wait for a response to a single
+	// attempt. It is only useful in combination with [WithRetry]. A zero
+	// value means each attempt is only bound by OverallTimeout.
+	AttemptTimeout time.Duration `env:""`
 	// RequestTimeout is the maximum time to wait for a health check response.
+	//
+	// Deprecated: Use OverallTimeout instead. RequestTimeout is used as
+	// OverallTimeout when the latter is not set.
 	RequestTimeout time.Duration `env:"" default:"3s"`
 }
 
@@ -26,4 +49,129 @@ var defaultConfig = Config{
 	RequestTimeout: 3 * time.Second,
 }
 
+// timeout returns the maximum time to wait for a health check response,
+// including any retries.
+func (c Config) timeout() time.Duration {
+	if c.OverallTimeout > 0 {
+		return c.OverallTimeout
+	}
+	if c.RequestTimeout > 0 {
+		return c.RequestTimeout
+	}
+	return 3 * time.Second
+}
+
 func DefaultConfig() Config { return defaultConfig }
+
+// Validate checks if the values of [Config] are valid. It returns an error
+// wrapping [ErrInvalidConfig] for each invalid value.
+func (c Config) Validate() error {
+	var err error
+	if c.TargetHostname != "" && strings.ContainsAny(c.TargetHostname, "/:?#@ ") && net.ParseIP(c.TargetHostname) == nil {
+		err = errors.Append(err, errors.Wrapf(ErrInvalidConfig, "TargetHostname %q is not a valid hostname or ip address", c.TargetHostname))
+	}
+	if c.TargetPath != "" && !strings.HasPrefix(c.TargetPath, "/") {
+		err = errors.Append(err, errors.Wrapf(ErrInvalidConfig, "TargetPath %q must start with a /", c.TargetPath))
+	}
+	if c.OverallTimeout < 0 {
+		err = errors.Append(err, errors.Wrapf(ErrInvalidConfig, "OverallTimeout %s must not be negative", c.OverallTimeout))
+	}
+	if c.AttemptTimeout < 0 {
+		err = errors.Append(err, errors.Wrapf(ErrInvalidConfig, "AttemptTimeout %s must not be negative", c.AttemptTimeout))
+	}
+	if c.RequestTimeout < 0 {
+		err = errors.Append(err, errors.Wrapf(ErrInvalidConfig, "RequestTimeout %s must not be negative", c.RequestTimeout))
+	}
+	return err
+}
+
+// ConfigFromEnv returns a [Config] based on [DefaultConfig] with its values
+// replaced by the values of the environment variables which are set. The
+// names of the environment variables are the upper snake cased names of the
+// [Config] fields, prefixed with prefix, eg. "HEALTHCHECK_TARGET_PORT" for
+// prefix "HEALTHCHECK". The returned [Config] is validated using
+// [Config.Validate].
+func ConfigFromEnv(prefix string) (Config, error) {
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+
+	conf := DefaultConfig()
+	rv := reflect.ValueOf(&conf).Elem()
+	rt := rv.Type()
+
+	var err error
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		if name == "" {
+			name = envName(field.Name)
+		}
+
+		name = prefix + name
+		val, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if setErr := setValue(rv.Field(i), val); setErr != nil {
+			err = errors.Append(err, errors.Wrap(errors.Wrapf(setErr, "%s", name), ErrInvalidEnv))
+		}
+	}
+	if err != nil {
+		return conf, err
+	}
+	return conf, conf.Validate()
+}
+
+// envName converts a field name to an upper snake cased environment variable
+// name, eg. "TargetHostname" becomes "TARGET_HOSTNAME".
+func envName(field string) string {
+	var sb strings.Builder
+	for i, r := range field {
+		if i > 0 && unicode.IsUpper(r) {
+			sb.WriteRune('_')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+func setValue(rv reflect.Value, val string) error {
+	if rv.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(val, 10, rv.Type().Bits())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(val, 10, rv.Type().Bits())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		rv.SetUint(n)
+	default:
+		return errors.Newf("unsupported type %s", rv.Type())
+	}
+	return nil
+}
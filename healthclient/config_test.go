@@ -0,0 +1,99 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		conf    Config
+		wantErr bool
+	}{
+		"default":          {conf: DefaultConfig()},
+		"empty":            {conf: Config{}},
+		"ipv6":             {conf: Config{TargetHostname: "::1"}},
+		"invalid hostname": {conf: Config{TargetHostname: "http://localhost"}, wantErr: true},
+		"invalid path":     {conf: Config{TargetPath: "healthy"}, wantErr: true},
+		"negative overall": {conf: Config{OverallTimeout: -time.Second}, wantErr: true},
+		"negative attempt": {conf: Config{AttemptTimeout: -time.Second}, wantErr: true},
+		"negative request": {conf: Config{RequestTimeout: -time.Second}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.conf.Validate()
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_timeout(t *testing.T) {
+	withRequestTimeout := DefaultConfig()
+	withRequestTimeout.RequestTimeout = 10 * time.Second
+
+	withOverallTimeout := DefaultConfig()
+	withOverallTimeout.OverallTimeout = time.Second
+
+	tests := map[string]struct {
+		conf Config
+		want time.Duration
+	}{
+		"default":         {conf: DefaultConfig(), want: 3 * time.Second},
+		"empty":           {conf: Config{}, want: 3 * time.Second},
+		"request timeout": {conf: withRequestTimeout, want: 10 * time.Second},
+		"overall timeout": {conf: withOverallTimeout, want: time.Second},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.conf.timeout())
+		})
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		conf, err := ConfigFromEnv("TEST")
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultConfig(), conf)
+	})
+	t.Run("values", func(t *testing.T) {
+		t.Setenv("TEST_TARGET_HOSTNAME", "example.com")
+		t.Setenv("TEST_TARGET_PORT", "8080")
+		t.Setenv("TEST_TARGET_PATH", "/healthz")
+		t.Setenv("TEST_OVERALL_TIMEOUT", "1s")
+		t.Setenv("TEST_ATTEMPT_TIMEOUT", "100ms")
+
+		conf, err := ConfigFromEnv("TEST")
+		assert.NoError(t, err)
+		assert.Equal(t, Config{
+			TargetHostname: "example.com",
+			TargetPort:     8080,
+			TargetPath:     "/healthz",
+			OverallTimeout: time.Second,
+			AttemptTimeout: 100 * time.Millisecond,
+			RequestTimeout: 3 * time.Second,
+		}, conf)
+	})
+	t.Run("invalid value", func(t *testing.T) {
+		t.Setenv("TEST_TARGET_PORT", "70000")
+
+		_, err := ConfigFromEnv("TEST_")
+		assert.ErrorIs(t, err, ErrInvalidEnv)
+	})
+	t.Run("invalid config", func(t *testing.T) {
+		t.Setenv("TEST_TARGET_PATH", "healthz")
+
+		_, err := ConfigFromEnv("TEST")
+		assert.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
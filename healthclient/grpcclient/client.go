@@ -0,0 +1,150 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpcclient provides a health check client which speaks the gRPC
+// health checking protocol (grpc.health.v1) and is configured using the same
+// [healthclient.Config] as the http based [healthclient.Client].
+package grpcclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const ErrRequestFailed errors.Msg = "request failed"
+
+// Client performs health checks on a target gRPC service using the gRPC
+// health checking protocol.
+type Client struct {
+	healthclient.Config
+
+	service  string
+	creds    credentials.TransportCredentials
+	dialOpts []grpc.DialOption
+}
+
+func New(conf healthclient.Config, opts ...Option) (*Client, error) {
+	c := Client{Config: conf}
+	return &c, c.With(opts...)
+}
+
+func (c *Client) With(opts ...Option) error {
+	var err error
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		err = errors.Append(err, opt(c))
+	}
+	return err
+}
+
+// Target returns the address of the target, of form "host:port".
+func (c *Client) Target() string {
+	host := c.Config.TargetHostname
+	if host == "" {
+		host = "localhost"
+	}
+	if c.Config.TargetPort == 0 {
+		return host
+	}
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(c.TargetPort), 10))
+}
+
+// Request performs a grpc.health.v1 Check request to the target and returns
+// the [healthcheck.Status] based on the serving status in the response.
+func (c *Client) Request(ctx context.Context) (healthcheck.Status, error) {
+	timeout := c.Config.OverallTimeout
+	if timeout == 0 {
+		timeout = c.Config.RequestTimeout
+	}
+	if timeout == 0 {
+		timeout = 3 * time.Second
+	}
+	if t, ok := ctx.Deadline(); !ok || timeout < time.Until(t) {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, timeout)
+		defer cancelFn()
+	}
+
+	creds := c.creds
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.NewClient(c.Target(), append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+	}, c.dialOpts...)...)
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+	defer func() { _ = conn.Close() }()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+		Service: c.service,
+	})
+	if err != nil {
+		return healthcheck.StatusUnknown, errors.Wrap(err, ErrRequestFailed)
+	}
+	return ServingStatus(resp.GetStatus()), nil
+}
+
+// ServingStatus returns the [healthcheck.Status] which represents the
+// provided [healthpb.HealthCheckResponse_ServingStatus].
+func ServingStatus(s healthpb.HealthCheckResponse_ServingStatus) healthcheck.Status {
+	switch s {
+	case healthpb.HealthCheckResponse_SERVING:
+		return healthcheck.StatusHealthy
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return healthcheck.StatusUnhealthy
+	default:
+		return healthcheck.StatusUnknown
+	}
+}
+
+type Option func(c *Client) error
+
+// WithService sets the name of the service to check. An empty name checks
+// the overall health of the target server.
+func WithService(name string) Option {
+	return func(c *Client) error {
+		c.service = name
+		return nil
+	}
+}
+
+const panicNilTLSConfig = "grpcclient.WithTLSConfig: tls.Config should not be nil"
+
+// WithTLSConfig secures the connection to the target using the provided
+// [tls.Config]. Without it, an insecure connection is used.
+func WithTLSConfig(conf *tls.Config) Option {
+	return func(c *Client) error {
+		if conf == nil {
+			panic(panicNilTLSConfig)
+		}
+
+		c.creds = credentials.NewTLS(conf)
+		return nil
+	}
+}
+
+// WithDialOptions adds [grpc.DialOption](s) which are used when connecting
+// to the target.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) error {
+		c.dialOpts = append(c.dialOpts, opts...)
+		return nil
+	}
+}
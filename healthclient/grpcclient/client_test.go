@@ -0,0 +1,69 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpcclient
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestClient_Request(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("bar", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conf := healthclient.Config{
+		TargetHostname: "127.0.0.1",
+		TargetPort:     uint16(lis.Addr().(*net.TCPAddr).Port),
+	}
+
+	tests := map[string]struct {
+		service    string
+		wantStatus healthcheck.Status
+		wantErr    bool
+	}{
+		"server":  {service: "", wantStatus: healthcheck.StatusHealthy},
+		"serving": {service: "foo", wantStatus: healthcheck.StatusHealthy},
+		"not serving": {
+			service:    "bar",
+			wantStatus: healthcheck.StatusUnhealthy,
+		},
+		"unknown service": {
+			service:    "baz",
+			wantStatus: healthcheck.StatusUnknown,
+			wantErr:    true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := New(conf, WithService(tc.service))
+			assert.NoError(t, err)
+
+			stat, err := client.Request(context.Background())
+			assert.Equal(t, tc.wantStatus, stat)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrRequestFailed)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestHook is called right before a health check request is sent. It may
+// modify the request, eg. to add headers.
+type RequestHook func(req *http.Request)
+
+// ResponseHook is called after a health check request is completed, including
+// any retries, with the response or error and the duration of the request.
+// The response is nil when err is not nil. A ResponseHook must not read or
+// close the response's body.
+type ResponseHook func(req *http.Request, resp *http.Response, dur time.Duration, err error)
+
+// WithOnRequest adds a [RequestHook] to the [Client]. Hooks are called in the
+// order they are added.
+func WithOnRequest(fn RequestHook) Option {
+	return func(c *Client) error {
+		if fn != nil {
+			c.onRequest = append(c.onRequest, fn)
+		}
+		return nil
+	}
+}
+
+// WithOnResponse adds a [ResponseHook] to the [Client]. Hooks are called in
+// the order they are added.
+func WithOnResponse(fn ResponseHook) Option {
+	return func(c *Client) error {
+		if fn != nil {
+			c.onResponse = append(c.onResponse, fn)
+		}
+		return nil
+	}
+}
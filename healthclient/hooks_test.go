@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	var haveHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		haveHeader = req.Header.Get("X-Probe")
+	}))
+	defer srv.Close()
+
+	var order []string
+	var haveCode int
+	var haveErr error
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&srv.URL),
+		WithOnRequest(func(req *http.Request) {
+			order = append(order, "request")
+			req.Header.Set("X-Probe", "yes")
+		}),
+		WithOnResponse(func(_ *http.Request, resp *http.Response, dur time.Duration, err error) {
+			order = append(order, "response")
+			haveCode, haveErr = resp.StatusCode, err
+			assert.Greater(t, dur, time.Duration(0))
+		}),
+	)
+	assert.NoError(t, err)
+
+	_, err = client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"request", "response"}, order)
+	assert.Equal(t, "yes", haveHeader)
+	assert.Equal(t, http.StatusOK, haveCode)
+	assert.NoError(t, haveErr)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"log"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+)
+
+// Logger logs the outcome of health check requests performed by a [Client].
+type Logger interface {
+	LogRequest(target string, dur time.Duration, stat healthcheck.Status, err error)
+	// LogWarning logs a warning about the configuration of the [Client].
+	LogWarning(msg string)
+}
+
+// ErrorLogger is an optional interface a [Logger] can implement to log errors
+// which prevent health check requests from being performed, e.g. an invalid
+// configuration of the [Client]. Loggers which do not implement ErrorLogger
+// log these errors using [Logger.LogWarning].
+type ErrorLogger interface {
+	LogError(msg string)
+}
+
+func logError(l Logger, msg string) {
+	if el, ok := l.(ErrorLogger); ok {
+		el.LogError(msg)
+	} else {
+		l.LogWarning(msg)
+	}
+}
+
+const panicNewNilLogger = "healthclient.NewLogger: log.Logger should not be nil"
+
+// NewLogger returns a [Logger] that uses a [log.Logger] to log health check
+// requests.
+func NewLogger(l *log.Logger) Logger {
+	if l == nil {
+		panic(panicNewNilLogger)
+	}
+	return &logger{l}
+}
+
+// DefaultLogger returns a [Logger] that uses [log.Default] to log health
+// check requests.
+func DefaultLogger() Logger { return &logger{log.Default()} }
+
+// NopLogger returns a [Logger] that does nothing.
+func NopLogger() Logger { return new(nopLogger) }
+
+const panicNilLogger = "healthclient.WithLogger: Logger should not be nil"
+
+// WithLogger sets the [Logger] which logs the outcome of each health check
+// request.
+func WithLogger(log Logger) Option {
+	return func(c *Client) error {
+		if log == nil {
+			panic(panicNilLogger)
+		}
+
+		c.log = log
+		return nil
+	}
+}
+
+func WithDefaultLogger() Option { return WithLogger(DefaultLogger()) }
+
+type logger struct{ *log.Logger }
+
+func (l *logger) LogRequest(target string, dur time.Duration, stat healthcheck.Status, err error) {
+	if err != nil {
+		l.Logger.Printf("health of %s is %s after %s: %v\n", target, stat, dur, err)
+	} else {
+		l.Logger.Printf("health of %s is %s after %s\n", target, stat, dur)
+	}
+}
+
+func (l *logger) LogWarning(msg string) {
+	l.Logger.Println("warning: " + msg)
+}
+
+func (l *logger) LogError(msg string) {
+	l.Logger.Println("error: " + msg)
+}
+
+type nopLogger struct{}
+
+func (*nopLogger) LogRequest(string, time.Duration, healthcheck.Status, error) {}
+
+func (*nopLogger) LogWarning(string) {}
+
+func (*nopLogger) LogError(string) {}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package healthclient
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+)
+
+const panicNewNilSlogLogger = "healthclient.NewSlogLogger: slog.Logger should not be nil"
+
+// NewSlogLogger returns a [Logger] that uses a [slog.Logger] to log health
+// check requests with structured attributes. Requests resulting in
+// [healthcheck.StatusHealthy] are logged at [slog.LevelInfo], all others at
+// [slog.LevelWarn].
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		panic(panicNewNilSlogLogger)
+	}
+	return &slogLogger{l}
+}
+
+type slogLogger struct{ *slog.Logger }
+
+func (l *slogLogger) LogRequest(target string, dur time.Duration, stat healthcheck.Status, err error) {
+	level := slog.LevelInfo
+	if stat != healthcheck.StatusHealthy || err != nil {
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("target", target),
+		slog.String("status", stat.String()),
+		slog.Duration("duration", dur),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	l.Logger.LogAttrs(context.Background(), level, "health check request", attrs...)
+}
+
+func (l *slogLogger) LogWarning(msg string) {
+	l.Logger.LogAttrs(context.Background(), slog.LevelWarn, msg)
+}
+
+func (l *slogLogger) LogError(msg string) {
+	l.Logger.LogAttrs(context.Background(), slog.LevelError, msg)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNewNilLogger, func() {
+			NewLogger(nil)
+		})
+	})
+
+	var buf bytes.Buffer
+	l := NewLogger(log.New(&buf, "", 0))
+	l.LogRequest("localhost:8080", time.Second, healthcheck.StatusUnknown, errors.New("some error"))
+	assert.Equal(t, "health of localhost:8080 is unknown after 1s: some error\n", buf.String())
+}
+
+func TestWithLogger(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilLogger, func() {
+			_ = WithLogger(nil)(nil)
+		})
+	})
+
+	var c Client
+	want := NopLogger()
+	assert.NoError(t, WithLogger(want)(&c))
+	assert.Same(t, want, c.log)
+}
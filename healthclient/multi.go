@@ -0,0 +1,114 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-pogo/healthcheck"
+)
+
+// Result is the result of a health check request to a single target.
+type Result struct {
+	Status healthcheck.Status
+	Err    error
+}
+
+// MultiClient performs health checks on multiple targets concurrently.
+type MultiClient struct {
+	clients map[string]*Client
+}
+
+const panicNilClient = "healthclient: Client should not be nil"
+
+// NewMultiClient creates a [MultiClient] which performs health checks on the
+// targets of the provided named [Client](s).
+func NewMultiClient(clients map[string]*Client) *MultiClient {
+	mc := MultiClient{clients: make(map[string]*Client, len(clients))}
+	for name, c := range clients {
+		if c == nil {
+			panic(panicNilClient)
+		}
+		mc.clients[name] = c
+	}
+	return &mc
+}
+
+// Request performs a health check request to all targets concurrently. It
+// returns the combined [healthcheck.Status] of all targets and the [Result]
+// of each individual target.
+func (mc *MultiClient) Request(ctx context.Context) (healthcheck.Status, map[string]Result) {
+	return requestAll(ctx, mc.clients, nil, 0)
+}
+
+// CheckAll creates a [Client] for each of the named [Config](s) and performs
+// a health check request to all targets in parallel, with at most limit
+// requests running at the same time. A limit of zero or less means no limit.
+// The provided [Option](s) are applied to each [Client]. It returns the
+// combined [healthcheck.Status] of all targets and the [Result] of each
+// individual target. A [Config] for which no [Client] can be created results
+// in a [Result] with [healthcheck.StatusUnknown] and the error.
+func CheckAll(ctx context.Context, confs map[string]Config, limit int, opts ...Option) (healthcheck.Status, map[string]Result) {
+	clients := make(map[string]*Client, len(confs))
+	results := make(map[string]Result, len(confs))
+	for name, conf := range confs {
+		c, err := New(conf, opts...)
+		if err != nil {
+			results[name] = Result{Status: healthcheck.StatusUnknown, Err: err}
+			continue
+		}
+		clients[name] = c
+	}
+	return requestAll(ctx, clients, results, limit)
+}
+
+func requestAll(ctx context.Context, clients map[string]*Client, results map[string]Result, limit int) (healthcheck.Status, map[string]Result) {
+	if results == nil {
+		results = make(map[string]Result, len(clients))
+	}
+	if len(clients) == 0 && len(results) == 0 {
+		return healthcheck.StatusUnknown, results
+	}
+
+	var sem chan struct{}
+	if limit > 0 {
+		sem = make(chan struct{}, limit)
+	}
+
+	var mut sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(len(clients))
+	for name, c := range clients {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+		go func(name string, c *Client) {
+			defer wg.Done()
+			stat, err := c.Request(ctx)
+			if sem != nil {
+				<-sem
+			}
+
+			mut.Lock()
+			results[name] = Result{Status: stat, Err: err}
+			mut.Unlock()
+		}(name, c)
+	}
+	wg.Wait()
+
+	return combineResults(results), results
+}
+
+func combineResults(results map[string]Result) healthcheck.Status {
+	stat := healthcheck.StatusUnknown
+	for _, res := range results {
+		stat = healthcheck.Combine(stat, res.Status)
+		if stat == healthcheck.StatusUnhealthy {
+			break
+		}
+	}
+	return stat
+}
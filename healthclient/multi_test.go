@@ -0,0 +1,95 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	urlpkg "net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiClient_Request(t *testing.T) {
+	t.Run("nil client", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilClient, func() {
+			NewMultiClient(map[string]*Client{"nil": nil})
+		})
+	})
+
+	healthy := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	newClient := func(url string) *Client {
+		c, err := New(Config{}, WithBindTargetBaseURL(&url))
+		assert.NoError(t, err)
+		return c
+	}
+
+	t.Run("all healthy", func(t *testing.T) {
+		stat, results := NewMultiClient(map[string]*Client{
+			"foo": newClient(healthy.URL),
+			"bar": newClient(healthy.URL),
+		}).Request(context.Background())
+
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.Len(t, results, 2)
+	})
+	t.Run("one unhealthy", func(t *testing.T) {
+		stat, results := NewMultiClient(map[string]*Client{
+			"foo": newClient(healthy.URL),
+			"bar": newClient(unhealthy.URL),
+		}).Request(context.Background())
+
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.Equal(t, Result{Status: healthcheck.StatusHealthy}, results["foo"])
+		assert.Equal(t, Result{Status: healthcheck.StatusUnhealthy}, results["bar"])
+	})
+}
+
+func TestCheckAll(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	url, err := urlpkg.Parse(srv.URL)
+	assert.NoError(t, err)
+	port, err := strconv.ParseUint(url.Port(), 10, 16)
+	assert.NoError(t, err)
+
+	conf := Config{TargetHostname: url.Hostname(), TargetPort: uint16(port), TargetPath: "/"}
+	confs := map[string]Config{"a": conf, "b": conf, "c": conf, "d": conf, "e": conf}
+
+	stat, results := CheckAll(context.Background(), confs, 2)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.Len(t, results, len(confs))
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	t.Run("invalid option", func(t *testing.T) {
+		stat, results := CheckAll(context.Background(), map[string]Config{"a": conf}, 0, WithTargetURL("/relative"))
+		assert.Equal(t, healthcheck.StatusUnknown, stat)
+		assert.ErrorIs(t, results["a"].Err, ErrInvalidTargetURL)
+	})
+}
@@ -5,14 +5,19 @@
 package healthclient
 
 import (
+	"bytes"
 	"crypto/tls"
+	"encoding/base64"
 	"net/http"
+	urlpkg "net/url"
+	"strings"
+	"time"
 
 	"github.com/go-pogo/easytls"
 	"github.com/go-pogo/errors"
 )
 
-const ErrUnknownTransportType errors.Msg = "cannot add tls.Config to http.Client.Transport of unknown type"
+const ErrUnknownTransportType errors.Msg = "cannot modify http.Client.Transport of unknown type"
 
 type Option func(c *Client) error
 
@@ -24,6 +29,150 @@ func WithHTTPClient(httpClient *http.Client) Option {
 	}
 }
 
+// WithMethod sets the http method used for health check requests, e.g.
+// [http.MethodHead]. The default method is [http.MethodGet].
+func WithMethod(method string) Option {
+	return func(c *Client) error {
+		c.method = method
+		return nil
+	}
+}
+
+// WithHeader adds a header to the health check requests. A "Host" header
+// overrides the host of the request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) error {
+		if c.header == nil {
+			c.header = make(http.Header, 2)
+		}
+		c.header.Add(key, value)
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the health check requests.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) error {
+		if c.header == nil {
+			c.header = make(http.Header, 2)
+		}
+		c.header.Set("User-Agent", ua)
+		return nil
+	}
+}
+
+// WithAuthorization sets the Authorization header of the health check
+// requests to value.
+func WithAuthorization(value string) Option {
+	return func(c *Client) error {
+		if c.header == nil {
+			c.header = make(http.Header, 2)
+		}
+		c.header.Set("Authorization", value)
+		return nil
+	}
+}
+
+// WithBearerToken authenticates the health check requests with the provided
+// bearer token.
+func WithBearerToken(token string) Option {
+	return WithAuthorization("Bearer " + token)
+}
+
+// WithBasicAuth authenticates the health check requests using HTTP Basic
+// Authentication with the provided username and password.
+func WithBasicAuth(username, password string) Option {
+	return WithAuthorization("Basic " + base64.StdEncoding.EncodeToString(
+		[]byte(username+":"+password),
+	))
+}
+
+// WithMaxLatency reports a target which responds healthy, but takes longer
+// than max to respond, as [healthcheck.StatusUnknown] together with an
+// [ErrMaxLatencyExceeded] error. A slow response is often the first symptom
+// of an overloaded target.
+func WithMaxLatency(max time.Duration) Option {
+	return func(c *Client) error {
+		c.maxLatency = max
+		return nil
+	}
+}
+
+// WithBodyMatcher validates the body of responses which indicate the target
+// is healthy using fn. When fn returns false, the target is reported as
+// [healthcheck.StatusUnknown] together with an [ErrUnexpectedBody] error.
+// This prevents eg. captive portals or misrouted requests from being
+// mistaken for a healthy target.
+func WithBodyMatcher(fn func(body []byte) bool) Option {
+	return func(c *Client) error {
+		c.bodyMatcher = fn
+		return nil
+	}
+}
+
+// WithExpectBody validates the body of responses which indicate the target
+// is healthy equals expect, ignoring leading and trailing white space. See
+// [WithBodyMatcher] for details.
+func WithExpectBody(expect string) Option {
+	want := []byte(strings.TrimSpace(expect))
+	return WithBodyMatcher(func(body []byte) bool {
+		return bytes.Equal(bytes.TrimSpace(body), want)
+	})
+}
+
+// WithMinInterval sets the minimum interval between health check requests
+// performed by [Client.Request]. Calls within this interval return the cached
+// result of the previous request. This protects the target from being
+// hammered when multiple components share the same [Client].
+func WithMinInterval(d time.Duration) Option {
+	return func(c *Client) error {
+		c.minInterval = d
+		return nil
+	}
+}
+
+const ErrTooManyRedirects errors.Msg = "too many redirects"
+
+// WithNoFollowRedirects prevents the [Client] from following redirects.
+// A redirect response is reported as an [InvalidStatusCode] error, unless its
+// status code is mapped otherwise, see [WithStatusCodeMapper].
+func WithNoFollowRedirects() Option {
+	return func(c *Client) error {
+		c.setCheckRedirect(func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		})
+		return nil
+	}
+}
+
+// WithMaxRedirects sets the maximum number of redirects the [Client] follows
+// before failing with an [ErrTooManyRedirects] error. The default is 10.
+func WithMaxRedirects(n int) Option {
+	return func(c *Client) error {
+		c.setCheckRedirect(func(_ *http.Request, via []*http.Request) error {
+			if len(via) > n {
+				return errors.New(ErrTooManyRedirects)
+			}
+			return nil
+		})
+		return nil
+	}
+}
+
+// setCheckRedirect sets fn as CheckRedirect on a shallow copy of the
+// [Client]'s [http.Client], or [http.DefaultClient] when none is set, so a
+// client provided with [WithHTTPClient] is never modified.
+func (c *Client) setCheckRedirect(fn func(req *http.Request, via []*http.Request) error) {
+	var cl http.Client
+	if c.httpClient != nil {
+		cl = *c.httpClient
+	} else {
+		cl = *http.DefaultClient
+	}
+	cl.CheckRedirect = fn
+	c.httpClient = &cl
+}
+
 const panicNilTLSConfig = "healthcheck.WithTLSConfig: tls.Config should not be nil"
 
 // WithTLSConfig sets the provided [tls.Config] to the [Client]'s internal
@@ -35,26 +184,52 @@ func WithTLSConfig(conf *tls.Config, opts ...easytls.Option) Option {
 			panic(panicNilTLSConfig)
 		}
 
-		if c.httpClient == nil {
-			c.httpClient = &http.Client{
-				Transport: &http.Transport{
-					TLSClientConfig: conf,
-				},
-			}
-		} else if c.httpClient.Transport == nil {
-			c.httpClient.Transport = &http.Transport{
-				TLSClientConfig: conf,
-			}
-		} else if t, ok := c.httpClient.Transport.(*http.Transport); ok {
-			t.TLSClientConfig = conf
-		} else {
-			return errors.New(ErrUnknownTransportType)
+		t, err := c.transport()
+		if err != nil {
+			return err
 		}
 
+		t.TLSClientConfig = conf
 		return easytls.Apply(conf, easytls.TargetClient, opts...)
 	}
 }
 
+// WithTargetURL sets the full url, including scheme, path and query, of the
+// target's health check endpoint. It overrides the target's url as created
+// from the [Config] and any bound values.
+func WithTargetURL(rawURL string) Option {
+	return func(c *Client) error {
+		url, err := urlpkg.Parse(rawURL)
+		if err != nil {
+			return errors.Wrap(err, ErrInvalidTargetURL)
+		}
+		if url.Scheme == "" || url.Host == "" {
+			return errors.Wrapf(ErrInvalidTargetURL, "url %q must be absolute", rawURL)
+		}
+
+		c.targetURL = url
+		return nil
+	}
+}
+
+const ErrInvalidScheme errors.Msg = "invalid scheme"
+
+// WithScheme sets the scheme, either "http" or "https", of the target's url
+// regardless of any [tls.Config] set to the [Client]. This allows probing
+// eg. a plaintext target behind a tls terminating proxy. It has no effect on
+// a url set with [WithTargetURL].
+func WithScheme(scheme string) Option {
+	return func(c *Client) error {
+		scheme = strings.ToLower(scheme)
+		if scheme != "http" && scheme != "https" {
+			return errors.Wrapf(ErrInvalidScheme, "scheme %q must be http or https", scheme)
+		}
+
+		c.scheme = scheme
+		return nil
+	}
+}
+
 // WithBindTargetBaseURL where ptr points to a strings which contains the base
 // url to the target server, of form "[scheme://]ipaddr|hostname[:port]",
 // without trailing slash.
@@ -71,3 +246,16 @@ func WithBindTargetPath(ptr *string) Option {
 		return nil
 	}
 }
+
+// WithRetry retries failed requests, like a refused connection while the
+// target is starting, until a total of attempts requests are made or the
+// request's deadline is exceeded. Before each retry the [Client] waits for
+// backoff, which doubles after each retry. Responses with an unexpected
+// status code are not retried.
+func WithRetry(attempts int, backoff time.Duration) Option {
+	return func(c *Client) error {
+		c.retryAttempts = attempts
+		c.retryBackoff = backoff
+		return nil
+	}
+}
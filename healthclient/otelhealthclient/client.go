@@ -0,0 +1,229 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package otelhealthclient provides OpenTelemetry instrumentation for
+// [healthclient.Client]. Each health check request is wrapped in a span, its
+// trace context is propagated to the target and the request's duration is
+// recorded as a metric, labeled with the target and resulting status.
+package otelhealthclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name.
+const ScopeName = "github.com/go-pogo/healthcheck/healthclient/otelhealthclient"
+
+const (
+	TargetKey     = attribute.Key("healthcheck.target")
+	StatusKey     = attribute.Key("healthcheck.status")
+	ErrorClassKey = attribute.Key("healthcheck.error.class")
+)
+
+// Client wraps a [healthclient.Client] with OpenTelemetry instrumentation.
+type Client struct {
+	*healthclient.Client
+
+	tracer trace.Tracer
+}
+
+const panicNilClient = "otelhealthclient.Wrap: healthclient.Client should not be nil"
+
+// Wrap instruments the provided [healthclient.Client] and returns it wrapped
+// in a [Client]. Without [Option](s), the global [otel.GetTracerProvider],
+// [otel.GetMeterProvider] and [otel.GetTextMapPropagator] are used. The
+// metrics [healthclient.Recorder] is added to any [healthclient.Recorder]
+// already set on c, see [healthclient.AddRecorder].
+//
+// [Client.Request], [Client.RequestReport], [Client.RequestAll] and
+// [Client.WaitForHealthy] are wrapped in a span. [healthclient.Client.Watch]
+// is not traced, as it polls for as long as its context is not done; its
+// requests are still recorded as metrics.
+func Wrap(c *healthclient.Client, opts ...Option) (*Client, error) {
+	if c == nil {
+		panic(panicNilClient)
+	}
+
+	var conf config
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&conf)
+		}
+	}
+	conf.defaults()
+
+	rec, err := NewRecorder(conf.meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	prop := conf.propagator
+	if err = c.With(
+		healthclient.AddRecorder(rec),
+		healthclient.WithContextHeaders(func(ctx context.Context, h http.Header) {
+			prop.Inject(ctx, propagation.HeaderCarrier(h))
+		}),
+	); err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Client: c,
+		tracer: conf.tracerProvider.Tracer(ScopeName),
+	}, nil
+}
+
+// Request performs a health check request to the target, within a new span.
+// See [healthclient.Client.Request] for details.
+func (c *Client) Request(ctx context.Context) (healthcheck.Status, error) {
+	ctx, span := c.startSpan(ctx, "healthclient.Request")
+	defer span.End()
+
+	stat, err := c.Client.Request(ctx)
+	endSpan(span, stat, err)
+	return stat, err
+}
+
+// RequestReport performs a health check request to the target, within a new
+// span. See [healthclient.Client.RequestReport] for details.
+func (c *Client) RequestReport(ctx context.Context) (*healthcheck.Report, error) {
+	ctx, span := c.startSpan(ctx, "healthclient.RequestReport")
+	defer span.End()
+
+	rep, err := c.Client.RequestReport(ctx)
+	stat := healthcheck.StatusUnknown
+	if rep != nil {
+		stat = rep.Status
+	}
+	endSpan(span, stat, err)
+	return rep, err
+}
+
+// RequestAll performs a health check request to all instances of the target,
+// within a new span. See [healthclient.Client.RequestAll] for details.
+func (c *Client) RequestAll(ctx context.Context) (healthcheck.Status, map[string]healthclient.Result, error) {
+	ctx, span := c.startSpan(ctx, "healthclient.RequestAll")
+	defer span.End()
+
+	stat, results, err := c.Client.RequestAll(ctx)
+	endSpan(span, stat, err)
+	return stat, results, err
+}
+
+// WaitForHealthy polls the target until it is healthy, within a single new
+// span. See [healthclient.Client.WaitForHealthy] for details.
+func (c *Client) WaitForHealthy(ctx context.Context, interval time.Duration) (healthcheck.Status, error) {
+	ctx, span := c.startSpan(ctx, "healthclient.WaitForHealthy")
+	defer span.End()
+
+	stat, err := c.Client.WaitForHealthy(ctx, interval)
+	endSpan(span, stat, err)
+	return stat, err
+}
+
+func (c *Client) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	var target string
+	if url, err := c.Client.TargetURL(); err == nil {
+		target = url.Host
+	}
+
+	return c.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(TargetKey.String(target)),
+	)
+}
+
+func endSpan(span trace.Span, stat healthcheck.Status, err error) {
+	span.SetAttributes(StatusKey.String(stat.String()))
+	if err != nil {
+		span.SetAttributes(ErrorClassKey.String(string(healthclient.ClassifyError(err))))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if stat != healthcheck.StatusHealthy {
+		span.SetStatus(codes.Error, stat.String())
+	}
+}
+
+type recorder struct {
+	duration metric.Float64Histogram
+}
+
+// NewRecorder returns a [healthclient.Recorder] which records the duration of
+// each health check request in seconds, as a histogram metric named
+// "healthcheck.client.request.duration", using a [metric.Meter] from mp.
+func NewRecorder(mp metric.MeterProvider) (healthclient.Recorder, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	hist, err := mp.Meter(ScopeName).Float64Histogram(
+		"healthcheck.client.request.duration",
+		metric.WithDescription("Duration of health check requests."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &recorder{duration: hist}, nil
+}
+
+func (r *recorder) RecordRequest(target string, dur time.Duration, stat healthcheck.Status, class healthclient.ErrorClass) {
+	attrs := []attribute.KeyValue{
+		TargetKey.String(target),
+		StatusKey.String(stat.String()),
+	}
+	if class != healthclient.ErrorClassNone {
+		attrs = append(attrs, ErrorClassKey.String(string(class)))
+	}
+
+	r.duration.Record(context.Background(), dur.Seconds(), metric.WithAttributes(attrs...))
+}
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+func (c *config) defaults() {
+	if c.tracerProvider == nil {
+		c.tracerProvider = otel.GetTracerProvider()
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = otel.GetMeterProvider()
+	}
+	if c.propagator == nil {
+		c.propagator = otel.GetTextMapPropagator()
+	}
+}
+
+type Option func(c *config)
+
+// WithTracerProvider sets the [trace.TracerProvider] used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the [metric.MeterProvider] used to record metrics.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// WithPropagator sets the [propagation.TextMapPropagator] used to propagate
+// the trace context to the target.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) { c.propagator = p }
+}
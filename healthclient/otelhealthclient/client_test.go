@@ -0,0 +1,120 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otelhealthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestWrap(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilClient, func() {
+			_, _ = Wrap(nil)
+		})
+	})
+
+	var haveTraceParent string
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveTraceParent = req.Header.Get("traceparent")
+	}))
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	hc, err := healthclient.New(healthclient.Config{}, healthclient.WithBindTargetBaseURL(&srv.URL))
+	assert.NoError(t, err)
+
+	client, err := Wrap(hc,
+		WithTracerProvider(tp),
+		WithMeterProvider(mp),
+		WithPropagator(propagation.TraceContext{}),
+	)
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "healthclient.Request", ended[0].Name())
+	assert.Contains(t, haveTraceParent, ended[0].SpanContext().TraceID().String())
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, "healthcheck.client.request.duration", rm.ScopeMetrics[0].Metrics[0].Name)
+}
+
+func TestWrap_existingRecorder(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	var recorded int
+	hc, err := healthclient.New(healthclient.Config{},
+		healthclient.WithBindTargetBaseURL(&srv.URL),
+		healthclient.WithRecorder(healthclient.RecorderFunc(func(string, time.Duration, healthcheck.Status, healthclient.ErrorClass) {
+			recorded++
+		})),
+	)
+	assert.NoError(t, err)
+
+	reader := sdkmetric.NewManualReader()
+	client, err := Wrap(hc, WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))))
+	assert.NoError(t, err)
+
+	_, _ = client.Request(context.Background())
+	assert.Equal(t, 1, recorded)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+}
+
+func TestClient_spans(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	hc, err := healthclient.New(healthclient.Config{}, healthclient.WithBindTargetBaseURL(&srv.URL))
+	assert.NoError(t, err)
+
+	spans := tracetest.NewSpanRecorder()
+	client, err := Wrap(hc, WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+	assert.NoError(t, err)
+
+	_, err = client.RequestReport(context.Background())
+	assert.NoError(t, err)
+	_, _, err = client.RequestAll(context.Background())
+	assert.NoError(t, err)
+	_, err = client.WaitForHealthy(context.Background(), time.Millisecond)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, span := range spans.Ended() {
+		names = append(names, span.Name())
+		assert.Contains(t, span.Attributes(), StatusKey.String("healthy"))
+	}
+	assert.Equal(t, []string{
+		"healthclient.RequestReport",
+		"healthclient.RequestAll",
+		"healthclient.WaitForHealthy",
+	}, names)
+}
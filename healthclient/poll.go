@@ -0,0 +1,104 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+// DefaultPollInterval is the interval used when polling the target with an
+// interval of 0 or less.
+const DefaultPollInterval = time.Second
+
+// WaitForHealthy polls the target every interval until it reports
+// [healthcheck.StatusHealthy] or ctx is done. It returns the last received
+// [healthcheck.Status] and error. When ctx is done before the target is
+// healthy and the last request did not fail, the error of ctx is returned.
+func (c *Client) WaitForHealthy(ctx context.Context, interval time.Duration) (healthcheck.Status, error) {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	for {
+		stat, err := c.Request(ctx)
+		if err == nil && stat == healthcheck.StatusHealthy {
+			return stat, nil
+		}
+
+		timer.Reset(interval)
+		select {
+		case <-ctx.Done():
+			if err == nil {
+				err = errors.WithStack(ctx.Err())
+			}
+			return stat, err
+
+		case <-timer.C:
+		}
+	}
+}
+
+// StatusChange describes a change of the target's [healthcheck.Status] as
+// observed by [Client.Watch].
+type StatusChange struct {
+	Old, New healthcheck.Status
+	// Err is the error returned by the request which resulted in New.
+	Err error
+	// Time at which the change was observed.
+	Time time.Time
+}
+
+// Watch polls the target every interval in a separate goroutine and sends
+// a [StatusChange] to the returned channel whenever the [healthcheck.Status]
+// of the target changes. The initial status is considered to be
+// [healthcheck.StatusUnknown]. The channel is closed when ctx is done.
+// An error is returned when the target's url is invalid.
+func (c *Client) Watch(ctx context.Context, interval time.Duration) (<-chan StatusChange, error) {
+	if _, err := c.TargetURL(); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ch := make(chan StatusChange)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		old := healthcheck.StatusUnknown
+		for {
+			stat, err := c.Request(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if stat != old {
+				select {
+				case ch <- StatusChange{Old: old, New: stat, Err: err, Time: time.Now()}:
+					old = stat
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// healthyAfter returns a [http.Handler] which responds unhealthy until it has
+// been requested n times.
+func healthyAfter(n int32) http.Handler {
+	var count atomic.Int32
+	return http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		if count.Add(1) <= n {
+			wri.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		wri.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestClient_WaitForHealthy(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		srv := httptest.NewServer(healthyAfter(2))
+		defer srv.Close()
+
+		client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+		assert.NoError(t, err)
+
+		stat, err := client.WaitForHealthy(context.Background(), time.Millisecond)
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+	})
+	t.Run("deadline exceeded", func(t *testing.T) {
+		srv := httptest.NewServer(healthyAfter(1000))
+		defer srv.Close()
+
+		client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+		assert.NoError(t, err)
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancelFn()
+
+		stat, err := client.WaitForHealthy(ctx, time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotEqual(t, healthcheck.StatusHealthy, stat)
+	})
+}
+
+func TestClient_Watch(t *testing.T) {
+	srv := httptest.NewServer(healthyAfter(2))
+	defer srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL))
+	assert.NoError(t, err)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	ch, err := client.Watch(ctx, time.Millisecond)
+	assert.NoError(t, err)
+
+	change := <-ch
+	assert.Equal(t, healthcheck.StatusUnknown, change.Old)
+	assert.Equal(t, healthcheck.StatusUnhealthy, change.New)
+
+	change = <-ch
+	assert.Equal(t, healthcheck.StatusUnhealthy, change.Old)
+	assert.Equal(t, healthcheck.StatusHealthy, change.New)
+
+	cancelFn()
+	for range ch {
+	}
+}
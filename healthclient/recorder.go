@@ -0,0 +1,119 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+// Recorder records the outcome of each health check request performed by a
+// [Client]. It can be used to feed metrics systems like Prometheus or
+// OpenTelemetry.
+type Recorder interface {
+	// RecordRequest is called after each health check request to target,
+	// which is the host of the request's url, with the duration of the
+	// request, the resulting [healthcheck.Status] and the [ErrorClass] of
+	// the error, if any.
+	RecordRequest(target string, dur time.Duration, stat healthcheck.Status, class ErrorClass)
+}
+
+// RecorderFunc is a func which implements [Recorder].
+type RecorderFunc func(target string, dur time.Duration, stat healthcheck.Status, class ErrorClass)
+
+func (fn RecorderFunc) RecordRequest(target string, dur time.Duration, stat healthcheck.Status, class ErrorClass) {
+	fn(target, dur, stat, class)
+}
+
+// WithRecorder sets the [Recorder] which records the outcome of each health
+// check request.
+func WithRecorder(r Recorder) Option {
+	return func(c *Client) error {
+		c.recorder = r
+		return nil
+	}
+}
+
+// AddRecorder adds a [Recorder] which records the outcome of each health
+// check request, in addition to any [Recorder] which is already set.
+func AddRecorder(r Recorder) Option {
+	return func(c *Client) error {
+		if c.recorder == nil {
+			c.recorder = r
+		} else if r != nil {
+			c.recorder = MultiRecorder(c.recorder, r)
+		}
+		return nil
+	}
+}
+
+// MultiRecorder returns a [Recorder] which records to each of the provided
+// [Recorder](s), in order.
+func MultiRecorder(recs ...Recorder) Recorder { return multiRecorder(recs) }
+
+type multiRecorder []Recorder
+
+func (m multiRecorder) RecordRequest(target string, dur time.Duration, stat healthcheck.Status, class ErrorClass) {
+	for _, r := range m {
+		if r != nil {
+			r.RecordRequest(target, dur, stat, class)
+		}
+	}
+}
+
+// ErrorClass classifies an error returned by a [Client]. Its values are
+// suitable to be used as metric labels.
+type ErrorClass string
+
+const (
+	ErrorClassNone       ErrorClass = ""
+	ErrorClassTimeout    ErrorClass = "timeout"
+	ErrorClassRequest    ErrorClass = "request"
+	ErrorClassDNS        ErrorClass = "dns"
+	ErrorClassRefused    ErrorClass = "connection_refused"
+	ErrorClassTLS        ErrorClass = "tls"
+	ErrorClassStatusCode ErrorClass = "status_code"
+	ErrorClassLatency    ErrorClass = "latency"
+	ErrorClassBody       ErrorClass = "body"
+	ErrorClassUnhealthy  ErrorClass = "unhealthy"
+	ErrorClassOther      ErrorClass = "other"
+)
+
+// ClassifyError returns the [ErrorClass] of err.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	switch classifyRequestError(err) {
+	case ErrTimeout:
+		return ErrorClassTimeout
+	case ErrHostNotFound:
+		return ErrorClassDNS
+	case ErrConnectionRefused:
+		return ErrorClassRefused
+	case ErrTLSHandshake:
+		return ErrorClassTLS
+	}
+
+	var codeErr *InvalidStatusCode
+	var unhealthyErr *UnhealthyError
+	switch {
+	case errors.As(err, &codeErr):
+		return ErrorClassStatusCode
+	case errors.As(err, &unhealthyErr):
+		return ErrorClassUnhealthy
+	case errors.Is(err, ErrMaxLatencyExceeded):
+		return ErrorClassLatency
+	case errors.Is(err, ErrUnexpectedBody):
+		return ErrorClassBody
+	case errors.Is(err, ErrRequestFailed):
+		return ErrorClassRequest
+	default:
+		return ErrorClassOther
+	}
+}
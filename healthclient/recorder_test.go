@@ -0,0 +1,95 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var haveTarget string
+	var haveStatus healthcheck.Status
+	var haveClass ErrorClass
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&srv.URL),
+		WithRecorder(RecorderFunc(func(target string, _ time.Duration, stat healthcheck.Status, class ErrorClass) {
+			haveTarget, haveStatus, haveClass = target, stat, class
+		})),
+	)
+	assert.NoError(t, err)
+
+	_, _ = client.Request(context.Background())
+
+	u, _ := url.Parse(srv.URL)
+	assert.Equal(t, u.Host, haveTarget)
+	assert.Equal(t, healthcheck.StatusUnknown, haveStatus)
+	assert.Equal(t, ErrorClassStatusCode, haveClass)
+}
+
+func TestAddRecorder(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	var have []string
+	record := func(name string) Recorder {
+		return RecorderFunc(func(string, time.Duration, healthcheck.Status, ErrorClass) {
+			have = append(have, name)
+		})
+	}
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&srv.URL),
+		WithRecorder(record("first")),
+		AddRecorder(record("second")),
+		AddRecorder(nil),
+	)
+	assert.NoError(t, err)
+
+	_, _ = client.Request(context.Background())
+	assert.Equal(t, []string{"first", "second"}, have)
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := map[ErrorClass]error{
+		ErrorClassNone:       nil,
+		ErrorClassTimeout:    errors.Wrap(context.DeadlineExceeded, ErrRequestFailed),
+		ErrorClassRequest:    errors.New(ErrRequestFailed),
+		ErrorClassDNS:        requestFailed(&net.DNSError{IsNotFound: true}),
+		ErrorClassRefused:    requestFailed(syscall.ECONNREFUSED),
+		ErrorClassTLS:        requestFailed(x509.UnknownAuthorityError{}),
+		ErrorClassStatusCode: errors.WithStack(&InvalidStatusCode{Code: 404}),
+		ErrorClassLatency:    errors.New(ErrMaxLatencyExceeded),
+		ErrorClassBody:       errors.New(ErrUnexpectedBody),
+		ErrorClassUnhealthy:  errors.WithStack(&UnhealthyError{}),
+		ErrorClassOther:      errors.New("some error"),
+	}
+	for want, err := range tests {
+		assert.Equal(t, want, ClassifyError(err), string(want))
+	}
+
+	t.Run("unwrapped", func(t *testing.T) {
+		assert.Equal(t, ErrorClassDNS, ClassifyError(&net.DNSError{IsNotFound: true}))
+		assert.Equal(t, ErrorClassRefused, ClassifyError(syscall.ECONNREFUSED))
+		assert.Equal(t, ErrorClassTLS, ClassifyError(x509.UnknownAuthorityError{}))
+	})
+}
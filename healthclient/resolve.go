@@ -0,0 +1,116 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const ErrResolveTargets errors.Msg = "failed to resolve targets"
+
+// TargetResolver resolves the addresses, of form "host:port", of all
+// instances of a target.
+type TargetResolver func(ctx context.Context) ([]string, error)
+
+// SRVTargets returns a [TargetResolver] which resolves the addresses of all
+// instances of a target using the SRV records of the service, see
+// [net.Resolver.LookupSRV].
+func SRVTargets(service, proto, name string) TargetResolver {
+	return func(ctx context.Context) ([]string, error) {
+		_, srvs, err := net.DefaultResolver.LookupSRV(ctx, service, proto, name)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		addrs := make([]string, 0, len(srvs))
+		for _, srv := range srvs {
+			addrs = append(addrs, net.JoinHostPort(
+				strings.TrimSuffix(srv.Target, "."),
+				strconv.FormatUint(uint64(srv.Port), 10),
+			))
+		}
+		return addrs, nil
+	}
+}
+
+// HostTargets returns a [TargetResolver] which resolves the addresses of all
+// instances of a target by looking up all ip addresses of host, see
+// [net.Resolver.LookupHost]. This is useful for eg. headless Kubernetes
+// services.
+func HostTargets(host string, port uint16) TargetResolver {
+	return func(ctx context.Context) ([]string, error) {
+		ips, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		p := strconv.FormatUint(uint64(port), 10)
+		addrs := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			addrs = append(addrs, net.JoinHostPort(ip, p))
+		}
+		return addrs, nil
+	}
+}
+
+// WithTargetResolver sets the [TargetResolver] which is used by
+// [Client.RequestAll] to discover all instances of the target.
+func WithTargetResolver(r TargetResolver) Option {
+	return func(c *Client) error {
+		c.targetResolver = r
+		return nil
+	}
+}
+
+// RequestAll performs a health check request to all instances of the target,
+// as resolved by the [TargetResolver] set with [WithTargetResolver]. Each
+// instance is requested using the [Client.TargetURL], with the connection
+// made to the instance's address. The Host header, TLS server name and
+// certificate verification remain those of the target. It returns the
+// combined [healthcheck.Status] of all instances and the [Result] of each
+// individual instance, keyed by address. When no [TargetResolver] is set,
+// only the target itself is requested.
+func (c *Client) RequestAll(ctx context.Context) (healthcheck.Status, map[string]Result, error) {
+	url, err := c.TargetURL()
+	if err != nil {
+		return healthcheck.StatusUnknown, nil, errors.Wrap(err, ErrRequestFailed)
+	}
+	if c.targetResolver == nil {
+		stat, _, err := c.requestURL(ctx, url, "", false)
+		results := map[string]Result{url.Host: {Status: stat, Err: err}}
+		return combineResults(results), results, nil
+	}
+
+	addrs, err := c.targetResolver(ctx)
+	if err != nil {
+		return healthcheck.StatusUnknown, nil, errors.Wrap(err, ErrResolveTargets)
+	}
+
+	var mut sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]Result, len(addrs))
+
+	wg.Add(len(addrs))
+	for _, addr := range addrs {
+		go func(addr string) {
+			defer wg.Done()
+			stat, _, err := c.requestURL(ctx, url, addr, false)
+
+			mut.Lock()
+			results[addr] = Result{Status: stat, Err: err}
+			mut.Unlock()
+		}(addr)
+	}
+	wg.Wait()
+
+	return combineResults(results), results, nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_RequestAll(t *testing.T) {
+	healthy := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	host := func(srv *httptest.Server) string {
+		u, err := url.Parse(srv.URL)
+		assert.NoError(t, err)
+		return u.Host
+	}
+
+	t.Run("without resolver", func(t *testing.T) {
+		client, err := New(Config{}, WithBindTargetBaseURL(&healthy.URL))
+		assert.NoError(t, err)
+
+		stat, results, err := client.RequestAll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.Equal(t, map[string]Result{
+			host(healthy): {Status: healthcheck.StatusHealthy},
+		}, results)
+	})
+	t.Run("with resolver", func(t *testing.T) {
+		client, err := New(Config{}, WithTargetResolver(func(context.Context) ([]string, error) {
+			return []string{host(healthy), host(unhealthy)}, nil
+		}))
+		assert.NoError(t, err)
+
+		stat, results, err := client.RequestAll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+		assert.Equal(t, map[string]Result{
+			host(healthy):   {Status: healthcheck.StatusHealthy},
+			host(unhealthy): {Status: healthcheck.StatusUnhealthy},
+		}, results)
+	})
+	t.Run("host targets", func(t *testing.T) {
+		addrs, err := HostTargets("localhost", 1234)(context.Background())
+		assert.NoError(t, err)
+		assert.NotEmpty(t, addrs)
+	})
+}
+
+func TestClient_RequestAll_tls(t *testing.T) {
+	type request struct{ host, serverName string }
+
+	var mut sync.Mutex
+	var have []request
+	handler := http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		mut.Lock()
+		have = append(have, request{host: req.Host, serverName: req.TLS.ServerName})
+		mut.Unlock()
+	})
+
+	srv1 := httptest.NewTLSServer(handler)
+	defer srv1.Close()
+	srv2 := httptest.NewTLSServer(handler)
+	defer srv2.Close()
+
+	addrs := []string{srv1.Listener.Addr().String(), srv2.Listener.Addr().String()}
+	baseURL := "https://example.com"
+
+	client, err := New(Config{},
+		WithHTTPClient(srv1.Client()),
+		WithBindTargetBaseURL(&baseURL),
+		WithTargetResolver(func(context.Context) ([]string, error) {
+			return addrs, nil
+		}),
+	)
+	assert.NoError(t, err)
+
+	stat, results, err := client.RequestAll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.Equal(t, map[string]Result{
+		addrs[0]: {Status: healthcheck.StatusHealthy},
+		addrs[1]: {Status: healthcheck.StatusHealthy},
+	}, results)
+	assert.Equal(t, []request{
+		{host: "example.com", serverName: "example.com"},
+		{host: "example.com", serverName: "example.com"},
+	}, have)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import "context"
+
+// ExitCodeInvalidConfig is the exit code returned by [Run] when the [Client]
+// cannot be created because of an invalid [Config] or [Option]. It differs
+// from the exit codes of [healthcheck.Status] so a misconfigured health check
+// can be told apart from an unhealthy or unknown target.
+const ExitCodeInvalidConfig = 101
+
+// Run creates a [Client] using conf and opts, performs a single health check
+// request to the target and returns the exit code of the resulting
+// [healthcheck.Status]. When the [Client] cannot be created, the error is
+// logged as an error, see [ErrorLogger], and [ExitCodeInvalidConfig] is
+// returned. The outcome is logged using [DefaultLogger], unless
+// another [Logger] is set with [WithLogger]. Run is intended to be used as
+// the main function of a Docker HEALTHCHECK command.
+//
+//	func main() {
+//		os.Exit(healthclient.Run(context.Background(), healthclient.DefaultConfig()))
+//	}
+func Run(ctx context.Context, conf Config, opts ...Option) int {
+	c, err := New(conf, append([]Option{WithDefaultLogger()}, opts...)...)
+	if err != nil {
+		logError(c.log, err.Error())
+		return ExitCodeInvalidConfig
+	}
+
+	stat, _ := c.Request(ctx)
+	return stat.ExitCode()
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	healthy := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	tests := map[string]struct {
+		opts []Option
+		want int
+	}{
+		"healthy":        {opts: []Option{WithBindTargetBaseURL(&healthy.URL)}, want: healthcheck.StatusHealthy.ExitCode()},
+		"unhealthy":      {opts: []Option{WithBindTargetBaseURL(&unhealthy.URL)}, want: healthcheck.StatusUnhealthy.ExitCode()},
+		"invalid option": {opts: []Option{WithTargetURL("/relative")}, want: ExitCodeInvalidConfig},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append(tc.opts, WithLogger(NewLogger(log.New(&buf, "", 0))))
+
+			assert.Equal(t, tc.want, Run(context.Background(), Config{}, opts...))
+			assert.NotEmpty(t, buf.String())
+		})
+	}
+
+	t.Run("log error", func(t *testing.T) {
+		var buf bytes.Buffer
+		Run(context.Background(), Config{},
+			WithTargetURL("/relative"),
+			WithLogger(NewLogger(log.New(&buf, "", 0))),
+		)
+		assert.True(t, strings.HasPrefix(buf.String(), "error: "), buf.String())
+	})
+}
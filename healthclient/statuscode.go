@@ -0,0 +1,90 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const ErrInvalidStatusCodeMapping errors.Msg = "invalid status code mapping"
+
+// StatusCodeMapper maps a http status code to a [healthcheck.Status]. It
+// returns false when the status code is not expected, in which case the
+// [Client] returns an [InvalidStatusCode] error.
+type StatusCodeMapper func(code int) (healthcheck.Status, bool)
+
+// DefaultStatusCodeMapper is the [StatusCodeMapper] used by [Client] when no
+// other [StatusCodeMapper] is set. It follows the conventions of
+// [healthcheck.HTTPHandler].
+func DefaultStatusCodeMapper(code int) (healthcheck.Status, bool) {
+	switch code {
+	case http.StatusTooEarly:
+		return healthcheck.StatusUnknown, true
+	case http.StatusOK, http.StatusNoContent:
+		return healthcheck.StatusHealthy, true
+	case http.StatusServiceUnavailable:
+		return healthcheck.StatusUnhealthy, true
+	default:
+		return healthcheck.StatusUnknown, false
+	}
+}
+
+// WithStatusCodeMapper sets the [StatusCodeMapper] which is used to interpret
+// the status codes of responses of the target.
+func WithStatusCodeMapper(fn StatusCodeMapper) Option {
+	return func(c *Client) error {
+		c.statusCodeMapper = fn
+		return nil
+	}
+}
+
+// WithStatusCodeMapping sets a [StatusCodeMapper] based on mapping. Its keys
+// are either exact status codes, like "204", or classes of status codes, like
+// "2xx". Exact status codes take precedence over classes.
+//
+//	WithStatusCodeMapping(map[string]healthcheck.Status{
+//		"2xx": healthcheck.StatusHealthy,
+//		"5xx": healthcheck.StatusUnhealthy,
+//	})
+func WithStatusCodeMapping(mapping map[string]healthcheck.Status) Option {
+	return func(c *Client) error {
+		codes := make(map[int]healthcheck.Status, len(mapping))
+		classes := make(map[int]healthcheck.Status, 5)
+
+		for key, stat := range mapping {
+			if len(key) != 3 {
+				return errors.Wrapf(ErrInvalidStatusCodeMapping, "key %q", key)
+			}
+			if strings.HasSuffix(strings.ToLower(key), "xx") {
+				class, err := strconv.Atoi(key[:1])
+				if err != nil || class < 1 || class > 5 {
+					return errors.Wrapf(ErrInvalidStatusCodeMapping, "key %q", key)
+				}
+				classes[class] = stat
+				continue
+			}
+
+			code, err := strconv.Atoi(key)
+			if err != nil || code < 100 || code > 599 {
+				return errors.Wrapf(ErrInvalidStatusCodeMapping, "key %q", key)
+			}
+			codes[code] = stat
+		}
+
+		c.statusCodeMapper = func(code int) (healthcheck.Status, bool) {
+			if stat, ok := codes[code]; ok {
+				return stat, true
+			}
+			stat, ok := classes[code/100]
+			return stat, ok
+		}
+		return nil
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithStatusCodeMapping(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for _, key := range []string{"", "20", "2000", "6xx", "0xx", "abc", "999"} {
+			t.Run(key, func(t *testing.T) {
+				_, err := New(Config{}, WithStatusCodeMapping(map[string]healthcheck.Status{
+					key: healthcheck.StatusHealthy,
+				}))
+				assert.ErrorIs(t, err, ErrInvalidStatusCodeMapping)
+			})
+		}
+	})
+
+	client, err := New(Config{}, WithStatusCodeMapping(map[string]healthcheck.Status{
+		"2xx": healthcheck.StatusHealthy,
+		"5XX": healthcheck.StatusUnhealthy,
+		"503": healthcheck.StatusUnknown,
+	}))
+	assert.NoError(t, err)
+
+	tests := map[int]struct {
+		wantStatus healthcheck.Status
+		wantOk     bool
+	}{
+		200: {healthcheck.StatusHealthy, true},
+		299: {healthcheck.StatusHealthy, true},
+		500: {healthcheck.StatusUnhealthy, true},
+		503: {healthcheck.StatusUnknown, true},
+		404: {healthcheck.StatusUnknown, false},
+	}
+	for code, tc := range tests {
+		stat, ok := client.statusCodeMapper(code)
+		assert.Equal(t, tc.wantStatus, stat, code)
+		assert.Equal(t, tc.wantOk, ok, code)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/go-pogo/easytls"
+	"github.com/go-pogo/errors"
+)
+
+// tlsConfig returns the [tls.Config] of the [Client]'s internal
+// [http.Transport]. When it does not exist yet, a new [tls.Config] is created
+// using [easytls.DefaultTLSConfig].
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = easytls.DefaultTLSConfig()
+	}
+	return t.TLSClientConfig, nil
+}
+
+// WithTLSClientCert adds the client certificate(s) to the [Client]'s
+// [tls.Config], which are presented to targets requiring mutual TLS.
+func WithTLSClientCert(certs ...tls.Certificate) Option {
+	return func(c *Client) error {
+		conf, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		conf.Certificates = append(conf.Certificates, certs...)
+		return nil
+	}
+}
+
+// WithTLSFiles loads the PEM encoded client certificate and key pair from
+// certFile and keyFile, and adds it to the [Client]'s [tls.Config]. When
+// caFile is not empty, the certificate authority (CA) from this file is added
+// to the [tls.Config.RootCAs] which are used to verify the target's
+// certificate.
+func WithTLSFiles(certFile, keyFile, caFile string) Option {
+	return func(c *Client) error {
+		conf, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		opts := []easytls.Option{easytls.KeyPair{CertFile: certFile, KeyFile: keyFile}}
+		if caFile != "" {
+			opts = append(opts, easytls.WithLoadX509RootCAs(easytls.CertificateFile(caFile)))
+		}
+		return easytls.Apply(conf, easytls.TargetClient, opts...)
+	}
+}
+
+const ErrLoadRootCAs errors.Msg = "failed to load root CAs"
+
+// WithTLSRootCAsFromFile adds the PEM encoded certificate authorities (CAs)
+// from the files at paths to the [tls.Config.RootCAs] which are used to verify
+// the target's certificate. A file may contain multiple certificates, like a
+// CA bundle.
+func WithTLSRootCAsFromFile(paths ...string) Option {
+	return func(c *Client) error {
+		conf, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		if conf.RootCAs == nil {
+			conf.RootCAs = x509.NewCertPool()
+		}
+
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrap(err, ErrLoadRootCAs)
+			}
+			if !conf.RootCAs.AppendCertsFromPEM(data) {
+				return errors.Wrapf(ErrLoadRootCAs, "no certificates found in %q", path)
+			}
+		}
+		return nil
+	}
+}
+
+// WithSystemCertPool sets the system's certificate pool as
+// [tls.Config.RootCAs], which are used to verify the target's certificate.
+// Any existing RootCAs are replaced, use this option before other options
+// which add RootCAs to extend the system's pool with additional CAs.
+func WithSystemCertPool() Option {
+	return func(c *Client) error {
+		conf, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return errors.Wrap(err, ErrLoadRootCAs)
+		}
+
+		conf.RootCAs = pool
+		return nil
+	}
+}
+
+// WithInsecureSkipVerify disables verification of the target's certificate
+// chain and host name. This makes the connection vulnerable to
+// machine-in-the-middle attacks and should only be used for probing targets
+// with self-signed certificates, eg. during development. A warning is logged
+// when a [Logger] is set.
+func WithInsecureSkipVerify() Option {
+	return func(c *Client) error {
+		conf, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		conf.InsecureSkipVerify = true
+		c.warnInsecure = true
+		return nil
+	}
+}
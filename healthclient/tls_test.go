@@ -0,0 +1,174 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+// newClientCert creates a self-signed client certificate and returns it
+// together with its PEM encoded certificate and key.
+func newClientCert(t *testing.T) (tls.Certificate, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "healthclient"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	assert.NoError(t, err)
+	return cert, certPEM, keyPEM
+}
+
+// newMTLSServer starts a [httptest.Server] which requires clients to present
+// a certificate signed by clientCA.
+func newMTLSServer(t *testing.T, clientCA tls.Certificate) *httptest.Server {
+	x, err := x509.ParseCertificate(clientCA.Certificate[0])
+	assert.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(x)
+
+	srv := httptest.NewUnstartedServer(healthcheck.SimpleHTTPHandler())
+	srv.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	srv.StartTLS()
+	return srv
+}
+
+func TestWithTLSClientCert(t *testing.T) {
+	cert, _, _ := newClientCert(t)
+	srv := newMTLSServer(t, cert)
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	client, err := New(Config{},
+		WithBindTargetBaseURL(&srv.URL),
+		WithTLSConfig(&tls.Config{RootCAs: pool}),
+		WithTLSClientCert(cert),
+	)
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+}
+
+func TestWithTLSFiles(t *testing.T) {
+	cert, certPEM, keyPEM := newClientCert(t)
+	srv := newMTLSServer(t, cert)
+	defer srv.Close()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	assert.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600))
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithTLSFiles(certFile, keyFile, caFile))
+	assert.NoError(t, err)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+}
+
+func TestWithTLSRootCAsFromFile(t *testing.T) {
+	srv := httptest.NewTLSServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}), 0o600))
+
+	t.Run("valid", func(t *testing.T) {
+		client, err := New(Config{},
+			WithBindTargetBaseURL(&srv.URL),
+			WithSystemCertPool(),
+			WithTLSRootCAsFromFile(caFile),
+		)
+		assert.NoError(t, err)
+
+		stat, err := client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+	})
+	t.Run("not exists", func(t *testing.T) {
+		_, err := New(Config{}, WithTLSRootCAsFromFile(filepath.Join(dir, "none.crt")))
+		assert.ErrorIs(t, err, ErrLoadRootCAs)
+	})
+	t.Run("no certificates", func(t *testing.T) {
+		empty := filepath.Join(dir, "empty.crt")
+		assert.NoError(t, os.WriteFile(empty, nil, 0o600))
+
+		_, err := New(Config{}, WithTLSRootCAsFromFile(empty))
+		assert.ErrorIs(t, err, ErrLoadRootCAs)
+	})
+}
+
+func TestWithInsecureSkipVerify(t *testing.T) {
+	srv := httptest.NewTLSServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client, err := New(Config{},
+		WithInsecureSkipVerify(),
+		WithBindTargetBaseURL(&srv.URL),
+		WithLogger(NewLogger(log.New(&buf, "", 0))),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "warning: tls certificate verification is disabled, connections are insecure\n", buf.String())
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	// TraceParentHeader is the W3C Trace Context header which identifies the
+	// incoming request in a tracing system.
+	TraceParentHeader = "traceparent"
+	// TraceStateHeader is the W3C Trace Context header which contains vendor
+	// specific trace information.
+	TraceStateHeader = "tracestate"
+	// RequestIDHeader is the header which contains the request's id.
+	RequestIDHeader = "X-Request-Id"
+)
+
+// ContextHeadersFunc adds headers derived from ctx to the header h of an
+// outgoing health check request.
+type ContextHeadersFunc func(ctx context.Context, h http.Header)
+
+// WithContextHeaders adds a [ContextHeadersFunc] which is called before each
+// health check request is sent. It can be used to integrate with tracing
+// libraries, eg. to inject the trace context using an OpenTelemetry
+// propagator.
+func WithContextHeaders(fn ContextHeadersFunc) Option {
+	return func(c *Client) error {
+		c.contextHeaders = append(c.contextHeaders, fn)
+		return nil
+	}
+}
+
+// WithTracePropagation adds the W3C Trace Context headers, as set with
+// [ContextWithTraceParent], and a [RequestIDHeader] header to each health
+// check request. The request id is taken from ctx using
+// [ContextWithRequestID] or is randomly generated when ctx does not contain
+// a request id.
+func WithTracePropagation() Option {
+	return WithContextHeaders(func(ctx context.Context, h http.Header) {
+		if tc, ok := ctx.Value(traceContextKey{}).(traceContext); ok {
+			h.Set(TraceParentHeader, tc.parent)
+			if tc.state != "" {
+				h.Set(TraceStateHeader, tc.state)
+			}
+		}
+
+		id, _ := ctx.Value(requestIDKey{}).(string)
+		if id == "" {
+			id = newRequestID()
+		}
+		h.Set(RequestIDHeader, id)
+	})
+}
+
+type traceContextKey struct{}
+
+type traceContext struct{ parent, state string }
+
+// ContextWithTraceParent returns a copy of ctx which contains the W3C Trace
+// Context traceparent and tracestate values that are propagated by
+// [WithTracePropagation].
+func ContextWithTraceParent(ctx context.Context, traceParent, traceState string) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceContext{traceParent, traceState})
+}
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx which contains the request id
+// that is propagated by [WithTracePropagation].
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
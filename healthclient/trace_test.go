@@ -0,0 +1,44 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTracePropagation(t *testing.T) {
+	var haveHeader http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveHeader = req.Header
+	}))
+	defer srv.Close()
+
+	client, err := New(Config{}, WithBindTargetBaseURL(&srv.URL), WithTracePropagation())
+	assert.NoError(t, err)
+
+	t.Run("from context", func(t *testing.T) {
+		const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+		ctx := ContextWithTraceParent(context.Background(), traceParent, "foo=bar")
+		ctx = ContextWithRequestID(ctx, "some-id")
+
+		_, err = client.Request(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, traceParent, haveHeader.Get(TraceParentHeader))
+		assert.Equal(t, "foo=bar", haveHeader.Get(TraceStateHeader))
+		assert.Equal(t, "some-id", haveHeader.Get(RequestIDHeader))
+	})
+	t.Run("generated request id", func(t *testing.T) {
+		_, err = client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, haveHeader.Get(TraceParentHeader))
+		assert.Len(t, haveHeader.Get(RequestIDHeader), 32)
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	urlpkg "net/url"
+	"time"
+
+	"github.com/go-pogo/errors"
+)
+
+// transport returns the [http.Transport] of the [Client]'s internal
+// [http.Client]. Both are created when they do not exist yet.
+func (c *Client) transport() (*http.Transport, error) {
+	if c.httpClient == nil {
+		c.httpClient = new(http.Client)
+	}
+	if c.httpClient.Transport == nil {
+		c.httpClient.Transport = newTransport()
+	}
+	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+		return t, nil
+	}
+	return nil, errors.New(ErrUnknownTransportType)
+}
+
+// newTransport creates a new [http.Transport] with the same settings as
+// [http.DefaultTransport]. Unlike [http.Transport.Clone], it does not
+// initialize [http.Transport.TLSClientConfig], which would otherwise result in
+// the [Client] using https.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// pinnedClient returns a shallow copy of httpClient with a clone of its
+// [http.Transport], which dials addr for every connection. Unlike replacing
+// the host of the request's url, this keeps the Host header, TLS server name
+// and certificate verification of the original target. Proxies are not used,
+// as the connection must be made to addr directly. The returned func closes
+// the idle connections of the cloned [http.Transport].
+func pinnedClient(httpClient *http.Client, addr string) (*http.Client, func(), error) {
+	rt := httpClient.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, nil, errors.New(ErrUnknownTransportType)
+	}
+
+	t = t.Clone()
+	t.Proxy = nil
+
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+	t.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return dial(ctx, network, addr)
+	}
+	if dialTLS := t.DialTLSContext; dialTLS != nil {
+		t.DialTLSContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialTLS(ctx, network, addr)
+		}
+	}
+
+	cl := *httpClient
+	cl.Transport = t
+	return &cl, t.CloseIdleConnections, nil
+}
+
+// netDialer returns the [net.Dialer] which is used by the [Client]'s internal
+// [http.Transport] to dial connections. It is created when it does not exist
+// yet.
+func (c *Client) netDialer() (*net.Dialer, error) {
+	if c.dialer != nil {
+		return c.dialer, nil
+	}
+
+	t, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	if c.customDial {
+		return nil, errors.New(ErrCustomDialContext)
+	}
+
+	c.dialer = &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	t.DialContext = c.dialer.DialContext
+	return c.dialer, nil
+}
+
+const ErrCustomDialContext errors.Msg = "cannot configure net.Dialer when a custom dial func is set"
+
+// WithDialContext sets the func which is used to dial connections to the
+// target. It can be used to eg. bind to a specific source interface or dial
+// via a SOCKS proxy. Other options which configure the [net.Dialer], like
+// [WithDualStack], [WithKeepAlive] and [WithResolver], cannot be combined
+// with a custom dial func.
+func WithDialContext(fn func(ctx context.Context, network, addr string) (net.Conn, error)) Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+		if c.dialer != nil {
+			return errors.New(ErrCustomDialContext)
+		}
+
+		t.DialContext = fn
+		c.customDial = true
+		return nil
+	}
+}
+
+// WithResolver sets the [net.Resolver] which is used to resolve the target's
+// hostname, eg. to use a custom DNS server.
+func WithResolver(r *net.Resolver) Option {
+	return func(c *Client) error {
+		d, err := c.netDialer()
+		if err != nil {
+			return err
+		}
+
+		d.Resolver = r
+		return nil
+	}
+}
+
+// WithDualStack configures how connections are made to a target hostname
+// which resolves to both IPv6 and IPv4 addresses. Connections over the primary
+// address family are attempted first, after fallbackDelay a connection over
+// the other family is attempted in parallel ("Happy Eyeballs"). The first
+// successful connection is used, so a target that is unreachable over one
+// family is still reachable over the other. A negative fallbackDelay disables
+// the parallel attempt, addresses of the other family are then only tried
+// after all addresses of the primary family failed.
+func WithDualStack(fallbackDelay time.Duration) Option {
+	return func(c *Client) error {
+		d, err := c.netDialer()
+		if err != nil {
+			return err
+		}
+
+		d.FallbackDelay = fallbackDelay
+		return nil
+	}
+}
+
+const ErrInvalidProxyURL errors.Msg = "invalid proxy url"
+
+// WithProxy sends all health check requests via the proxy at proxyURL,
+// instead of the proxy configured via the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+// environment variables, which are honored by default.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := urlpkg.Parse(proxyURL)
+		if err != nil {
+			return errors.Wrap(err, ErrInvalidProxyURL)
+		}
+
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.Proxy = http.ProxyURL(u)
+		return nil
+	}
+}
+
+// WithNoProxy disables the use of any proxy, including the proxy configured
+// via the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithNoProxy() Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.Proxy = nil
+		return nil
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle (keep-alive) connections
+// to keep in the connection pool of the [Client]'s internal [http.Transport].
+func WithMaxIdleConns(n int) Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.MaxIdleConns = n
+		t.MaxIdleConnsPerHost = n
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets the maximum amount of time an idle (keep-alive)
+// connection remains idle before closing itself.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.IdleConnTimeout = d
+		return nil
+	}
+}
+
+// WithKeepAlive sets the interval between keep-alive probes of an active
+// network connection. A negative value disables keep-alive probes.
+func WithKeepAlive(d time.Duration) Option {
+	return func(c *Client) error {
+		dialer, err := c.netDialer()
+		if err != nil {
+			return err
+		}
+
+		dialer.KeepAlive = d
+		return nil
+	}
+}
+
+// WithTLSHandshakeTimeout sets the maximum amount of time to wait for a TLS
+// handshake.
+func WithTLSHandshakeTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.TLSHandshakeTimeout = d
+		return nil
+	}
+}
+
+// WithDisableKeepAlives disables HTTP keep-alives, forcing a new connection
+// for each health check request. This helps to detect broken connections at
+// load balancer level.
+func WithDisableKeepAlives() Option {
+	return func(c *Client) error {
+		t, err := c.transport()
+		if err != nil {
+			return err
+		}
+
+		t.DisableKeepAlives = true
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDualStack(t *testing.T) {
+	t.Run("unknown transport", func(t *testing.T) {
+		_, err := New(Config{},
+			WithHTTPClient(&http.Client{Transport: roundTripperFunc(nil)}),
+			WithDualStack(time.Millisecond),
+		)
+		assert.ErrorIs(t, err, ErrUnknownTransportType)
+	})
+
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	assert.NoError(t, err)
+
+	// localhost may resolve to both ::1 and 127.0.0.1, while the server only
+	// listens on 127.0.0.1
+	target := "localhost:" + port
+	client, err := New(Config{}, WithBindTargetBaseURL(&target), WithDualStack(time.Millisecond))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, client.dialer.FallbackDelay)
+
+	stat, err := client.Request(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+}
+
+func TestWithProxy(t *testing.T) {
+	var haveURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		haveURL = req.URL.String()
+	}))
+	defer proxy.Close()
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := New(Config{}, WithProxy("://"))
+		assert.ErrorIs(t, err, ErrInvalidProxyURL)
+	})
+	t.Run("proxy", func(t *testing.T) {
+		client, err := New(Config{TargetHostname: "example.com", TargetPath: "/healthy"}, WithProxy(proxy.URL))
+		assert.NoError(t, err)
+
+		stat, err := client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.Equal(t, "http://example.com/healthy", haveURL)
+	})
+	t.Run("no proxy", func(t *testing.T) {
+		client, err := New(Config{}, WithProxy(proxy.URL), WithNoProxy())
+		assert.NoError(t, err)
+
+		tr, err := client.transport()
+		assert.NoError(t, err)
+		assert.Nil(t, tr.Proxy)
+	})
+}
+
+func TestTransportOptions(t *testing.T) {
+	client, err := New(Config{},
+		WithMaxIdleConns(2),
+		WithIdleConnTimeout(time.Minute),
+		WithKeepAlive(-1),
+		WithTLSHandshakeTimeout(time.Second),
+		WithDisableKeepAlives(),
+	)
+	assert.NoError(t, err)
+
+	tr, err := client.transport()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tr.MaxIdleConns)
+	assert.Equal(t, 2, tr.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, tr.IdleConnTimeout)
+	assert.Equal(t, time.Second, tr.TLSHandshakeTimeout)
+	assert.True(t, tr.DisableKeepAlives)
+	assert.Equal(t, time.Duration(-1), client.dialer.KeepAlive)
+	assert.Nil(t, client.TLSConfig())
+}
+
+func TestWithDialContext(t *testing.T) {
+	srv := httptest.NewServer(healthcheck.SimpleHTTPHandler())
+	defer srv.Close()
+
+	t.Run("dial", func(t *testing.T) {
+		var haveAddr string
+		var d net.Dialer
+
+		client, err := New(Config{TargetHostname: "example.com"},
+			WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+				haveAddr = addr
+				return d.DialContext(ctx, network, srv.Listener.Addr().String())
+			}),
+		)
+		assert.NoError(t, err)
+
+		stat, err := client.Request(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, healthcheck.StatusHealthy, stat)
+		assert.Equal(t, "example.com:80", haveAddr)
+	})
+	t.Run("combined with dialer option", func(t *testing.T) {
+		_, err := New(Config{}, WithDialContext(nil), WithKeepAlive(time.Second))
+		assert.ErrorIs(t, err, ErrCustomDialContext)
+
+		_, err = New(Config{}, WithKeepAlive(time.Second), WithDialContext(nil))
+		assert.ErrorIs(t, err, ErrCustomDialContext)
+	})
+}
+
+func TestWithResolver(t *testing.T) {
+	r := &net.Resolver{PreferGo: true}
+	client, err := New(Config{}, WithResolver(r))
+	assert.NoError(t, err)
+	assert.Same(t, r, client.dialer.Resolver)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package healthstatsd provides a [healthcheck.EventSink] which exports the
+// status and duration of health checks as StatsD metrics over udp. Tags are
+// written in the DogStatsD format, which is supported by Datadog and
+// Telegraf's statsd input with datadog_extensions enabled.
+package healthstatsd
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const ErrDialFailed errors.Msg = "dial failed"
+
+// DefaultPrefix is the default prefix of all metric names.
+const DefaultPrefix = "healthcheck."
+
+var _ healthcheck.EventSink = (*Exporter)(nil)
+
+// Exporter exports [healthcheck.Event](s) as StatsD metrics. For each
+// [healthcheck.EventCheckCompleted] event it sends a "check.healthy" gauge,
+// which is 1 when the check is healthy and 0 otherwise, and a
+// "check.duration" timing in milliseconds, both tagged with the check's name
+// and status. For each [healthcheck.EventHealthChanged] event it sends a
+// "healthy" gauge, tagged with the status.
+type Exporter struct {
+	prefix string
+	tags   []string
+
+	mut  sync.Mutex
+	conn net.Conn
+	buf  []byte
+}
+
+// Option is an option for an [Exporter] created with [New].
+type Option func(e *Exporter)
+
+// WithPrefix sets the prefix of all metric names. The default is
+// [DefaultPrefix].
+func WithPrefix(prefix string) Option {
+	return func(e *Exporter) { e.prefix = prefix }
+}
+
+// WithTags adds tags, of form "key:value", to all metrics.
+func WithTags(tags ...string) Option {
+	return func(e *Exporter) { e.tags = append(e.tags, tags...) }
+}
+
+// New creates an [Exporter] which sends metrics to the StatsD server at
+// addr, of form "host:port". It returns an error wrapping [ErrDialFailed]
+// when addr cannot be resolved.
+func New(addr string, opts ...Option) (*Exporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrDialFailed)
+	}
+
+	e := Exporter{
+		prefix: DefaultPrefix,
+		conn:   conn,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&e)
+		}
+	}
+	return &e, nil
+}
+
+// HandleEvent sends the metrics for [healthcheck.Event] e. Write errors are
+// ignored, as is usual with StatsD.
+func (e *Exporter) HandleEvent(ev healthcheck.Event) {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	e.buf = e.buf[:0]
+	switch ev.Kind {
+	case healthcheck.EventCheckCompleted:
+		tags := []string{"check:" + ev.Name, "status:" + ev.Status.String()}
+		e.appendMetric("check.healthy", healthy(ev.Status), "g", tags)
+		e.buf = append(e.buf, '\n')
+		e.appendMetric("check.duration", strconv.FormatFloat(float64(ev.Duration.Microseconds())/1000, 'f', -1, 64), "ms", tags)
+
+	case healthcheck.EventHealthChanged:
+		e.appendMetric("healthy", healthy(ev.Status), "g", []string{"status:" + ev.Status.String()})
+
+	default:
+		return
+	}
+
+	_, _ = e.conn.Write(e.buf)
+}
+
+// Close closes the connection to the StatsD server.
+func (e *Exporter) Close() error {
+	return errors.WithStack(e.conn.Close())
+}
+
+func (e *Exporter) appendMetric(name, value, typ string, tags []string) {
+	e.buf = append(e.buf, e.prefix...)
+	e.buf = append(e.buf, name...)
+	e.buf = append(e.buf, ':')
+	e.buf = append(e.buf, value...)
+	e.buf = append(e.buf, '|')
+	e.buf = append(e.buf, typ...)
+
+	if len(e.tags) != 0 || len(tags) != 0 {
+		e.buf = append(e.buf, "|#"...)
+		e.buf = append(e.buf, strings.Join(append(tags, e.tags...), ",")...)
+	}
+}
+
+func healthy(stat healthcheck.Status) string {
+	if stat == healthcheck.StatusHealthy {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthstatsd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExporter_HandleEvent(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	read := func() string {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		buf := make([]byte, 1024)
+		n, _, err := conn.ReadFrom(buf)
+		assert.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	exp, err := New(conn.LocalAddr().String(), WithPrefix("app.health."), WithTags("env:test"))
+	assert.NoError(t, err)
+	defer exp.Close()
+
+	exp.HandleEvent(healthcheck.Event{
+		Kind:     healthcheck.EventCheckCompleted,
+		Name:     "db",
+		Status:   healthcheck.StatusUnhealthy,
+		Duration: 1500 * time.Microsecond,
+	})
+	assert.Equal(t,
+		"app.health.check.healthy:0|g|#check:db,status:unhealthy,env:test\n"+
+			"app.health.check.duration:1.5|ms|#check:db,status:unhealthy,env:test",
+		read(),
+	)
+
+	exp.HandleEvent(healthcheck.Event{
+		Kind:   healthcheck.EventHealthChanged,
+		Status: healthcheck.StatusHealthy,
+	})
+	assert.Equal(t, "app.health.healthy:1|g|#status:healthy,env:test", read())
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("invalid address")
+	assert.ErrorIs(t, err, ErrDialFailed)
+}
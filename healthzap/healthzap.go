@@ -0,0 +1,122 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package healthzap provides a logger which uses a [zap.Logger] to log health
+// status events and health check requests. It implements both the
+// [healthcheck.CheckLogger] and [healthclient.Logger] interfaces.
+package healthzap
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	_ healthcheck.CheckLogger = (*Logger)(nil)
+	_ healthclient.Logger     = (*Logger)(nil)
+)
+
+// Logger logs health status events and health check requests using a
+// [zap.Logger].
+type Logger struct {
+	log *zap.Logger
+}
+
+const panicNilLogger = "healthzap.NewLogger: zap.Logger should not be nil"
+
+// NewLogger returns a [Logger] that uses l to log. Changes to, and requests
+// resulting in, [healthcheck.StatusHealthy] are logged at [zap.InfoLevel], all
+// others at [zap.WarnLevel]. The lifecycle of individual health checks is
+// logged at [zap.DebugLevel].
+func NewLogger(l *zap.Logger) *Logger {
+	if l == nil {
+		panic(panicNilLogger)
+	}
+	return &Logger{log: l}
+}
+
+// LogHealthChanged logs a change of the health [healthcheck.Status]. The
+// status of each individual [healthcheck.HealthChecker] is added as an object
+// field named "checks".
+func (l *Logger) LogHealthChanged(status, oldStatus healthcheck.Status, statuses map[string]healthcheck.Status) {
+	fields := []zap.Field{
+		zap.Stringer("status", status),
+		zap.Stringer("old_status", oldStatus),
+	}
+	if len(statuses) != 0 {
+		fields = append(fields, zap.Object("checks", checks(statuses)))
+	}
+
+	l.log.Log(level(status, nil), "health changed", fields...)
+}
+
+// LogCheckStarted logs the start of the [healthcheck.HealthChecker]
+// registered with name.
+func (l *Logger) LogCheckStarted(name string) {
+	l.log.Debug("check started", zap.String("name", name))
+}
+
+// LogCheckCompleted logs the [healthcheck.Result] of the
+// [healthcheck.HealthChecker] registered with name.
+func (l *Logger) LogCheckCompleted(name string, res healthcheck.Result) {
+	fields := []zap.Field{
+		zap.String("name", name),
+		zap.Stringer("status", res.Status),
+		zap.Duration("duration", res.Duration),
+	}
+	if res.Err != nil {
+		fields = append(fields, zap.Error(res.Err))
+	}
+
+	l.log.Debug("check completed", fields...)
+}
+
+// LogRequest logs the outcome of a health check request.
+func (l *Logger) LogRequest(target string, dur time.Duration, stat healthcheck.Status, err error) {
+	fields := []zap.Field{
+		zap.String("target", target),
+		zap.Stringer("status", stat),
+		zap.Duration("duration", dur),
+	}
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+
+	l.log.Log(level(stat, err), "health check request", fields...)
+}
+
+// LogWarning logs a warning about the configuration of a
+// [healthclient.Client].
+func (l *Logger) LogWarning(msg string) { l.log.Warn(msg) }
+
+// LogError logs an error which prevents a [healthclient.Client] from
+// performing health check requests.
+func (l *Logger) LogError(msg string) { l.log.Error(msg) }
+
+func level(stat healthcheck.Status, err error) zapcore.Level {
+	if stat != healthcheck.StatusHealthy || err != nil {
+		return zap.WarnLevel
+	}
+	return zap.InfoLevel
+}
+
+type checks map[string]healthcheck.Status
+
+func (c checks) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		enc.AddString(name, c[name].String())
+	}
+	return nil
+}
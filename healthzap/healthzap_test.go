@@ -0,0 +1,108 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthzap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNewLogger(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilLogger, func() {
+		NewLogger(nil)
+	})
+}
+
+func TestLogger_LogHealthChanged(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	NewLogger(zap.New(core)).LogHealthChanged(healthcheck.StatusUnhealthy, healthcheck.StatusHealthy, map[string]healthcheck.Status{
+		"db":    healthcheck.StatusUnhealthy,
+		"redis": healthcheck.StatusHealthy,
+	})
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, zap.WarnLevel, entries[0].Level)
+	assert.Equal(t, "health changed", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"status":     "unhealthy",
+		"old_status": "healthy",
+		"checks":     map[string]any{"db": "unhealthy", "redis": "healthy"},
+	}, entries[0].ContextMap())
+}
+
+func TestLogger_LogCheckCompleted(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := NewLogger(zap.New(core))
+	l.LogCheckStarted("db")
+	l.LogCheckCompleted("db", healthcheck.Result{
+		Status:   healthcheck.StatusUnhealthy,
+		Err:      errors.New("connection refused"),
+		Duration: time.Second,
+	})
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, zap.DebugLevel, entries[0].Level)
+	assert.Equal(t, "check started", entries[0].Message)
+	assert.Equal(t, map[string]any{"name": "db"}, entries[0].ContextMap())
+
+	assert.Equal(t, zap.DebugLevel, entries[1].Level)
+	assert.Equal(t, "check completed", entries[1].Message)
+	assert.Equal(t, map[string]any{
+		"name":     "db",
+		"status":   "unhealthy",
+		"duration": time.Second,
+		"error":    "connection refused",
+	}, entries[1].ContextMap())
+}
+
+func TestLogger_LogRequest(t *testing.T) {
+	tests := map[string]struct {
+		stat      healthcheck.Status
+		err       error
+		wantLevel zapcore.Level
+		wantMap   map[string]any
+	}{
+		"healthy": {
+			stat:      healthcheck.StatusHealthy,
+			wantLevel: zap.InfoLevel,
+			wantMap: map[string]any{
+				"target":   "localhost:8080",
+				"status":   "healthy",
+				"duration": time.Second,
+			},
+		},
+		"error": {
+			stat:      healthcheck.StatusUnhealthy,
+			err:       errors.New("connection refused"),
+			wantLevel: zap.WarnLevel,
+			wantMap: map[string]any{
+				"target":   "localhost:8080",
+				"status":   "unhealthy",
+				"duration": time.Second,
+				"error":    "connection refused",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			NewLogger(zap.New(core)).LogRequest("localhost:8080", time.Second, tc.stat, tc.err)
+
+			entries := logs.AllUntimed()
+			assert.Len(t, entries, 1)
+			assert.Equal(t, tc.wantLevel, entries[0].Level)
+			assert.Equal(t, tc.wantMap, entries[0].ContextMap())
+		})
+	}
+}
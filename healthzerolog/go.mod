@@ -0,0 +1,21 @@
+module github.com/go-pogo/healthcheck/healthzerolog
+
+go 1.23
+
+require (
+	github.com/go-pogo/healthcheck v0.0.0-20261017203950-54cc65100655
+	github.com/rs/zerolog v1.35.1
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-pogo/easytls v0.1.3 // indirect
+	github.com/go-pogo/errors v0.11.2 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,111 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package healthzerolog provides a logger which uses a [zerolog.Logger] to
+// log health status events and health check requests. It implements both the
+// [healthcheck.CheckLogger] and [healthclient.Logger] interfaces.
+package healthzerolog
+
+import (
+	"sort"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/go-pogo/healthcheck/healthclient"
+	"github.com/rs/zerolog"
+)
+
+var (
+	_ healthcheck.CheckLogger = (*Logger)(nil)
+	_ healthclient.Logger     = (*Logger)(nil)
+)
+
+// Logger logs health status events and health check requests using a
+// [zerolog.Logger].
+type Logger struct {
+	log zerolog.Logger
+}
+
+// NewLogger returns a [Logger] that uses l to log. Changes to, and requests
+// resulting in, [healthcheck.StatusHealthy] are logged at
+// [zerolog.InfoLevel], all others at [zerolog.WarnLevel]. The lifecycle of
+// individual health checks is logged at [zerolog.DebugLevel].
+func NewLogger(l zerolog.Logger) *Logger {
+	return &Logger{log: l}
+}
+
+// LogHealthChanged logs a change of the health [healthcheck.Status]. The
+// status of each individual [healthcheck.HealthChecker] is added as a
+// dictionary named "checks".
+func (l *Logger) LogHealthChanged(status, oldStatus healthcheck.Status, statuses map[string]healthcheck.Status) {
+	e := l.log.WithLevel(level(status, nil)).
+		Stringer("status", status).
+		Stringer("old_status", oldStatus)
+
+	if len(statuses) != 0 {
+		names := make([]string, 0, len(statuses))
+		for name := range statuses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		dict := zerolog.Dict()
+		for _, name := range names {
+			dict.Stringer(name, statuses[name])
+		}
+		e.Dict("checks", dict)
+	}
+
+	e.Msg("health changed")
+}
+
+// LogCheckStarted logs the start of the [healthcheck.HealthChecker]
+// registered with name.
+func (l *Logger) LogCheckStarted(name string) {
+	l.log.Debug().Str("name", name).Msg("check started")
+}
+
+// LogCheckCompleted logs the [healthcheck.Result] of the
+// [healthcheck.HealthChecker] registered with name.
+func (l *Logger) LogCheckCompleted(name string, res healthcheck.Result) {
+	e := l.log.Debug().
+		Str("name", name).
+		Stringer("status", res.Status).
+		Dur("duration", res.Duration)
+
+	if res.Err != nil {
+		e.Err(res.Err)
+	}
+
+	e.Msg("check completed")
+}
+
+// LogRequest logs the outcome of a health check request.
+func (l *Logger) LogRequest(target string, dur time.Duration, stat healthcheck.Status, err error) {
+	e := l.log.WithLevel(level(stat, err)).
+		Str("target", target).
+		Stringer("status", stat).
+		Dur("duration", dur)
+
+	if err != nil {
+		e.Err(err)
+	}
+
+	e.Msg("health check request")
+}
+
+// LogWarning logs a warning about the configuration of a
+// [healthclient.Client].
+func (l *Logger) LogWarning(msg string) { l.log.Warn().Msg(msg) }
+
+// LogError logs an error which prevents a [healthclient.Client] from
+// performing health check requests.
+func (l *Logger) LogError(msg string) { l.log.Error().Msg(msg) }
+
+func level(stat healthcheck.Status, err error) zerolog.Level {
+	if stat != healthcheck.StatusHealthy || err != nil {
+		return zerolog.WarnLevel
+	}
+	return zerolog.InfoLevel
+}
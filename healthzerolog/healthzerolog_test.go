@@ -0,0 +1,73 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthzerolog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_LogHealthChanged(t *testing.T) {
+	var buf bytes.Buffer
+	NewLogger(zerolog.New(&buf)).LogHealthChanged(healthcheck.StatusUnhealthy, healthcheck.StatusHealthy, map[string]healthcheck.Status{
+		"redis": healthcheck.StatusHealthy,
+		"db":    healthcheck.StatusUnhealthy,
+	})
+
+	assert.JSONEq(t, `{
+		"level": "warn",
+		"status": "unhealthy",
+		"old_status": "healthy",
+		"checks": {"db": "unhealthy", "redis": "healthy"},
+		"message": "health changed"
+	}`, buf.String())
+}
+
+func TestLogger_LogCheckCompleted(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(zerolog.New(&buf))
+	l.LogCheckStarted("db")
+	l.LogCheckCompleted("db", healthcheck.Result{
+		Status:   healthcheck.StatusUnhealthy,
+		Err:      errors.New("connection refused"),
+		Duration: time.Second,
+	})
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, `{"level":"debug","name":"db","message":"check started"}`, string(lines[0]))
+	assert.JSONEq(t, `{"level":"debug","name":"db","status":"unhealthy","duration":1000,"error":"connection refused","message":"check completed"}`, string(lines[1]))
+}
+
+func TestLogger_LogRequest(t *testing.T) {
+	tests := map[string]struct {
+		stat healthcheck.Status
+		err  error
+		want string
+	}{
+		"healthy": {
+			stat: healthcheck.StatusHealthy,
+			want: `{"level":"info","target":"localhost:8080","status":"healthy","duration":1000,"message":"health check request"}`,
+		},
+		"error": {
+			stat: healthcheck.StatusUnhealthy,
+			err:  errors.New("connection refused"),
+			want: `{"level":"warn","target":"localhost:8080","status":"unhealthy","duration":1000,"error":"connection refused","message":"health check request"}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			NewLogger(zerolog.New(&buf)).LogRequest("localhost:8080", time.Second, tc.stat, tc.err)
+			assert.JSONEq(t, tc.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package heartbeat provides a [healthcheck.HealthChecker] which detects
+// stuck or deadlocked internal worker goroutines. Workers periodically call
+// [Monitor.Beat], the [Monitor] reports unhealthy when no beat is received
+// within its deadline.
+package heartbeat
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrNoBeat           errors.Msg = "no beat received yet"
+	ErrDeadlineExceeded errors.Msg = "no beat received within deadline"
+)
+
+var _ healthcheck.HealthChecker = (*Monitor)(nil)
+
+// Monitor monitors the liveness of worker goroutines. Use a separate
+// [Monitor] for each worker which should be monitored individually.
+type Monitor struct {
+	deadline time.Duration
+	last     atomic.Int64
+}
+
+const panicInvalidDeadline = "heartbeat.NewMonitor: deadline should be greater than zero"
+
+// NewMonitor creates a [Monitor] which considers its worker(s) unhealthy when
+// no beat is received within deadline.
+func NewMonitor(deadline time.Duration) *Monitor {
+	if deadline <= 0 {
+		panic(panicInvalidDeadline)
+	}
+	return &Monitor{deadline: deadline}
+}
+
+// Beat records a beat. It should be called periodically by the monitored
+// worker(s), more often than the deadline of the [Monitor].
+func (m *Monitor) Beat() { m.last.Store(time.Now().UnixNano()) }
+
+// LastBeat returns the time of the last beat, or the zero [time.Time] when no
+// beat is received yet.
+func (m *Monitor) LastBeat() time.Time {
+	if last := m.last.Load(); last != 0 {
+		return time.Unix(0, last)
+	}
+	return time.Time{}
+}
+
+// CheckHealth checks if a beat is received within the deadline, see
+// [Monitor.Check].
+func (m *Monitor) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := m.Check(ctx)
+	return stat
+}
+
+// Check returns [healthcheck.StatusUnhealthy] and an error when no beat is
+// received within the deadline. It returns [healthcheck.StatusUnknown] and an
+// error when no beat is received yet.
+func (m *Monitor) Check(context.Context) (healthcheck.Status, error) {
+	last := m.LastBeat()
+	if last.IsZero() {
+		return healthcheck.StatusUnknown, errors.New(ErrNoBeat)
+	}
+	if since := time.Since(last); since > m.deadline {
+		return healthcheck.StatusUnhealthy, errors.Wrapf(ErrDeadlineExceeded, "last beat %s ago", since.Round(time.Millisecond))
+	}
+	return healthcheck.StatusHealthy, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heartbeat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMonitor(t *testing.T) {
+	assert.PanicsWithValue(t, panicInvalidDeadline, func() {
+		_ = NewMonitor(0)
+	})
+}
+
+func TestMonitor_Check(t *testing.T) {
+	m := NewMonitor(30 * time.Millisecond)
+	ctx := context.Background()
+
+	stat, err := m.Check(ctx)
+	assert.Equal(t, healthcheck.StatusUnknown, stat)
+	assert.ErrorIs(t, err, ErrNoBeat)
+	assert.True(t, m.LastBeat().IsZero())
+
+	m.Beat()
+	stat, err = m.Check(ctx)
+	assert.Equal(t, healthcheck.StatusHealthy, stat)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), m.LastBeat(), time.Second)
+
+	time.Sleep(40 * time.Millisecond)
+	stat, err = m.Check(ctx)
+	assert.Equal(t, healthcheck.StatusUnhealthy, stat)
+	assert.ErrorIs(t, err, ErrDeadlineExceeded)
+
+	m.Beat()
+	assert.Equal(t, healthcheck.StatusHealthy, m.CheckHealth(ctx))
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+)
+
+// CheckFunc performs a health check and returns its [Status] and, when
+// available, the error that caused it.
+type CheckFunc func(ctx context.Context) (Status, error)
+
+// Interceptor intercepts health checks performed by a [Checker]. It is called
+// for each run of [Checker.CheckHealth], with an empty name, and for each
+// registered [HealthChecker], with the name it is registered with. An
+// Interceptor should call next, and may modify its context, [Status] and
+// error, or do work before and after it, like tracing.
+type Interceptor func(ctx context.Context, name string, next CheckFunc) (Status, error)
+
+const panicNilInterceptor = "healthcheck.WithInterceptor: Interceptor should not be nil"
+
+// WithInterceptor adds an [Interceptor] to the [Checker]. Interceptors are
+// called in the order they are added, the first being the outermost.
+func WithInterceptor(ic Interceptor) Option {
+	if ic == nil {
+		panic(panicNilInterceptor)
+	}
+
+	return func(c *Checker) error {
+		c.interceptors = append(c.interceptors, ic)
+		return nil
+	}
+}
+
+func (h *Checker) intercept(ctx context.Context, name string, fn CheckFunc) (Status, error) {
+	for i := len(h.interceptors) - 1; i >= 0; i-- {
+		ic, next := h.interceptors[i], fn
+		fn = func(ctx context.Context) (Status, error) {
+			return ic(ctx, name, next)
+		}
+	}
+	return fn(ctx)
+}
+
+// checkFunc returns a [CheckFunc] which uses the Check method of check when
+// available, so the error that caused its [Status] is not lost.
+func checkFunc(check HealthChecker) CheckFunc {
+	if ec, ok := check.(errorChecker); ok {
+		return ec.Check
+	}
+	return func(ctx context.Context) (Status, error) {
+		return check.CheckHealth(ctx), nil
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInterceptor(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilInterceptor, func() {
+			_ = WithInterceptor(nil)
+		})
+	})
+
+	t.Run("order", func(t *testing.T) {
+		wantErr := errors.New("connection refused")
+
+		var calls []string
+		record := func(prefix string) Interceptor {
+			return func(ctx context.Context, name string, next CheckFunc) (Status, error) {
+				calls = append(calls, prefix+" before "+name)
+				stat, err := next(ctx)
+				if name == "db" {
+					assert.Same(t, wantErr, err)
+				}
+				calls = append(calls, prefix+" after "+name)
+				return stat, err
+			}
+		}
+
+		c, err := New(
+			WithInterceptor(record("1")),
+			WithInterceptor(record("2")),
+			WithHealthChecker("db", &errorCheck{stat: StatusUnhealthy, err: wantErr}),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusUnhealthy, c.CheckHealth(context.Background()))
+		assert.Equal(t, []string{
+			"1 before ", "2 before ",
+			"1 before db", "2 before db",
+			"2 after db", "1 after db",
+			"2 after ", "1 after ",
+		}, calls)
+	})
+
+	t.Run("modify status", func(t *testing.T) {
+		c, err := New(
+			WithInterceptor(func(ctx context.Context, name string, next CheckFunc) (Status, error) {
+				stat, err := next(ctx)
+				if name == "optional" {
+					return StatusHealthy, err
+				}
+				return stat, err
+			}),
+			WithHealthChecker("optional", &errorCheck{stat: StatusUnhealthy}),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusHealthy, c.CheckHealth(context.Background()))
+	})
+}
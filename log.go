@@ -6,12 +6,28 @@ package healthcheck
 
 import (
 	"log"
+	"sync"
+	"time"
 )
 
 type Logger interface {
 	LogHealthChanged(newStatus, oldStatus Status, statuses map[string]Status)
 }
 
+// CheckLogger is a [Logger] which also logs the lifecycle of each individual
+// [HealthChecker] registered in a [Checker]. When the [Logger] of a [Checker],
+// or the [Logger] set using [WithCheckLogger], implements CheckLogger, it is
+// called before and after each run of a [HealthChecker].
+type CheckLogger interface {
+	Logger
+	// LogCheckStarted is called before the [HealthChecker] registered with
+	// name is run.
+	LogCheckStarted(name string)
+	// LogCheckCompleted is called after the [HealthChecker] registered with
+	// name has run, with its [Result].
+	LogCheckCompleted(name string, res Result)
+}
+
 const panicNewNilLogger = "healthcheck.NewLogger: log.Logger should not be nil"
 
 // NewLogger returns a [Logger] that uses a [log.Logger] to log health
@@ -23,9 +39,42 @@ func NewLogger(l *log.Logger) Logger {
 	return &logger{l}
 }
 
+const (
+	// DefaultLogLimit is the maximum number of health status changes logged
+	// by [DefaultLogger] within [DefaultLogWindow].
+	DefaultLogLimit = 10
+	// DefaultLogWindow is the window in which [DefaultLogger] logs at most
+	// [DefaultLogLimit] health status changes.
+	DefaultLogWindow = time.Minute
+)
+
 // DefaultLogger returns a [Logger] that uses [log.Default] to log health
-// status events.
-func DefaultLogger() Logger { return &logger{log.Default()} }
+// status events. Flapping health statuses are throttled using
+// [DefaultLogLimit] and [DefaultLogWindow], see [NewThrottledLogger].
+func DefaultLogger() Logger {
+	return NewThrottledLogger(log.Default(), DefaultLogLimit, DefaultLogWindow)
+}
+
+const panicInvalidLogLimit = "healthcheck.NewThrottledLogger: limit and window should be greater than zero"
+
+// NewThrottledLogger returns a [Logger] that uses a [log.Logger] to log at
+// most limit health status changes within window. When this limit is
+// exceeded, a single message is logged stating the changes are suppressed.
+// At the end of the window a summary of the suppressed changes is logged.
+func NewThrottledLogger(l *log.Logger, limit int, window time.Duration) Logger {
+	if l == nil {
+		panic(panicNewNilLogger)
+	}
+	if limit <= 0 || window <= 0 {
+		panic(panicInvalidLogLimit)
+	}
+
+	return &throttledLogger{
+		logger: logger{l},
+		limit:  limit,
+		window: window,
+	}
+}
 
 // NopLogger returns a [Logger] that does nothing.
 func NopLogger() Logger { return new(nopLogger) }
@@ -43,6 +92,55 @@ func (l *logger) LogHealthChanged(status, oldStatus Status, statuses map[string]
 	}
 }
 
+type throttledLogger struct {
+	logger
+	limit  int
+	window time.Duration
+
+	mut        sync.Mutex
+	start      time.Time
+	count      int
+	suppressed int
+	status     Status
+	timer      *time.Timer
+}
+
+func (l *throttledLogger) LogHealthChanged(status, oldStatus Status, statuses map[string]Status) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	if now := time.Now(); l.timer == nil && now.Sub(l.start) >= l.window {
+		l.start = now
+		l.count = 0
+	}
+
+	l.count++
+	if l.count <= l.limit {
+		l.logger.LogHealthChanged(status, oldStatus, statuses)
+		return
+	}
+
+	l.suppressed++
+	l.status = status
+	if l.timer == nil {
+		l.Logger.Printf("health changed %d times in the last %s, suppressing\n", l.count, l.window)
+		l.timer = time.AfterFunc(l.window-time.Since(l.start), l.summarize)
+	}
+}
+
+// summarize logs the number of suppressed health status changes and starts a
+// new window.
+func (l *throttledLogger) summarize() {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.Logger.Printf("suppressed %d health changes, health is %s\n", l.suppressed, l.status)
+	l.start = time.Now()
+	l.count = 0
+	l.suppressed = 0
+	l.timer = nil
+}
+
 type nopLogger struct{}
 
 func (*nopLogger) LogHealthChanged(_, _ Status, _ map[string]Status) {}
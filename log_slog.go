@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+const panicNewNilSlogLogger = "healthcheck.NewSlogLogger: slog.Logger should not be nil"
+
+// NewSlogLogger returns a [Logger] that uses a [slog.Logger] to log health
+// status events with structured attributes. The status of each individual
+// [HealthChecker] is added as a group named "checks". Changes to
+// [StatusHealthy] are logged at [slog.LevelInfo], all others at
+// [slog.LevelWarn]. The returned [Logger] implements [CheckLogger] and logs
+// the lifecycle of each individual [HealthChecker] at [slog.LevelDebug].
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		panic(panicNewNilSlogLogger)
+	}
+	return &slogLogger{l}
+}
+
+type slogLogger struct{ *slog.Logger }
+
+func (l *slogLogger) LogHealthChanged(status, oldStatus Status, statuses map[string]Status) {
+	level := slog.LevelInfo
+	if status != StatusHealthy {
+		level = slog.LevelWarn
+	}
+
+	attrs := []slog.Attr{
+		slog.String("status", status.String()),
+		slog.String("old_status", oldStatus.String()),
+	}
+	if len(statuses) != 0 {
+		names := make([]string, 0, len(statuses))
+		for name := range statuses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		checks := make([]any, 0, len(names))
+		for _, name := range names {
+			checks = append(checks, slog.String(name, statuses[name].String()))
+		}
+		attrs = append(attrs, slog.Group("checks", checks...))
+	}
+
+	l.Logger.LogAttrs(context.Background(), level, "health changed", attrs...)
+}
+
+func (l *slogLogger) LogCheckStarted(name string) {
+	l.Logger.LogAttrs(context.Background(), slog.LevelDebug, "check started",
+		slog.String("name", name),
+	)
+}
+
+func (l *slogLogger) LogCheckCompleted(name string, res Result) {
+	attrs := []slog.Attr{
+		slog.String("name", name),
+		slog.String("status", res.Status.String()),
+		slog.Duration("duration", res.Duration),
+	}
+	if res.Err != nil {
+		attrs = append(attrs, slog.String("error", res.Err.Error()))
+	}
+
+	l.Logger.LogAttrs(context.Background(), slog.LevelDebug, "check completed", attrs...)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package healthcheck
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNewNilSlogLogger, func() {
+			NewSlogLogger(nil)
+		})
+	})
+
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})))
+
+	l.LogHealthChanged(StatusUnhealthy, StatusHealthy, map[string]Status{
+		"redis": StatusHealthy,
+		"db":    StatusUnhealthy,
+	})
+	assert.Equal(t, "level=WARN msg=\"health changed\" status=unhealthy old_status=healthy checks.db=unhealthy checks.redis=healthy\n", buf.String())
+
+	t.Run("check lifecycle", func(t *testing.T) {
+		var buf bytes.Buffer
+		l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))).(CheckLogger)
+
+		l.LogCheckStarted("db")
+		l.LogCheckCompleted("db", Result{
+			Status:   StatusUnhealthy,
+			Err:      errors.New("connection refused"),
+			Duration: time.Second,
+		})
+		assert.Equal(t, "level=DEBUG msg=\"check started\" name=db\n"+
+			"level=DEBUG msg=\"check completed\" name=db status=unhealthy duration=1s error=\"connection refused\"\n",
+			buf.String())
+	})
+}
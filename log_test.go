@@ -0,0 +1,104 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type syncBuffer struct {
+	mut sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines() []string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return strings.Split(strings.TrimSpace(b.buf.String()), "\n")
+}
+
+func TestNewThrottledLogger(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNewNilLogger, func() {
+			NewThrottledLogger(nil, 1, time.Second)
+		})
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicInvalidLogLimit, func() {
+			NewThrottledLogger(log.Default(), 0, time.Second)
+		})
+	})
+
+	t.Run("throttle", func(t *testing.T) {
+		var buf syncBuffer
+		l := NewThrottledLogger(log.New(&buf, "", 0), 2, 50*time.Millisecond)
+
+		stat := StatusHealthy
+		for i := 0; i < 5; i++ {
+			l.LogHealthChanged(stat, -stat, nil)
+			stat = -stat
+		}
+		assert.Equal(t, []string{
+			"health changed from unhealthy to healthy",
+			"health changed from healthy to unhealthy",
+			"health changed 3 times in the last 50ms, suppressing",
+		}, buf.lines())
+
+		assert.Eventually(t, func() bool {
+			return len(buf.lines()) == 4
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, "suppressed 3 health changes, health is healthy", buf.lines()[3])
+
+		l.LogHealthChanged(StatusUnhealthy, StatusHealthy, nil)
+		assert.Equal(t, "health changed from healthy to unhealthy", buf.lines()[4])
+	})
+}
+
+type lifecycleLogger struct {
+	nopLogger
+	events []string
+	result Result
+}
+
+func (l *lifecycleLogger) LogCheckStarted(name string) {
+	l.events = append(l.events, "started "+name)
+}
+
+func (l *lifecycleLogger) LogCheckCompleted(name string, res Result) {
+	l.events = append(l.events, "completed "+name)
+	l.result = res
+}
+
+func TestCheckLogger(t *testing.T) {
+	wantErr := errors.New("connection refused")
+
+	var log lifecycleLogger
+	c, err := New(
+		WithLogger(&log),
+		WithHealthChecker("db", &errorCheck{stat: StatusUnhealthy, err: wantErr}),
+	)
+	assert.NoError(t, err)
+	c.CheckHealth(context.Background())
+
+	assert.Equal(t, []string{"started db", "completed db"}, log.events)
+	assert.Equal(t, StatusUnhealthy, log.result.Status)
+	assert.Same(t, wantErr, log.result.Err)
+	assert.Equal(t, wantErr, c.Results()["db"].Err)
+}
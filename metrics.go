@@ -0,0 +1,101 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"bufio"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	textContentType        = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+const panicNilMetricsChecker = "healthcheck.MetricsHandler: Checker should not be nil"
+
+// MetricsHandler returns a [http.Handler] which renders the health [Status]
+// of checker and the [Result] of each registered [HealthChecker] in the
+// Prometheus text exposition format, or the OpenMetrics text format when
+// requested by the client using the Accept header. This allows scraping
+// without a dependency on a Prometheus client library. The following metrics
+// are exposed, where a status is 1 for [StatusHealthy], 0 for
+// [StatusUnknown] and -1 for [StatusUnhealthy]:
+//
+//   - healthcheck_status
+//   - healthcheck_check_status{name="..."}
+//   - healthcheck_check_duration_seconds{name="..."}
+//   - healthcheck_check_last_run_timestamp_seconds{name="..."}
+//
+// The handler does not trigger any health checks, it reports the results of
+// the last run.
+func MetricsHandler(checker *Checker) http.Handler {
+	if checker == nil {
+		panic(panicNilMetricsChecker)
+	}
+
+	return http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		openMetrics := strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text")
+		if openMetrics {
+			wri.Header().Set("Content-Type", openMetricsContentType)
+		} else {
+			wri.Header().Set("Content-Type", textContentType)
+		}
+
+		buf := bufio.NewWriter(wri)
+		writeMetrics(buf, checker.Status(), checker.Results())
+		if openMetrics {
+			_, _ = buf.WriteString("# EOF\n")
+		}
+		_ = buf.Flush()
+	})
+}
+
+func writeMetrics(w *bufio.Writer, stat Status, results map[string]Result) {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeMetricHeader(w, "healthcheck_status", "Combined health status of all checks, 1 is healthy, 0 is unknown and -1 is unhealthy.")
+	_, _ = w.WriteString("healthcheck_status " + strconv.Itoa(int(stat)) + "\n")
+
+	if len(names) == 0 {
+		return
+	}
+
+	writeMetricHeader(w, "healthcheck_check_status", "Health status of the check, 1 is healthy, 0 is unknown and -1 is unhealthy.")
+	for _, name := range names {
+		writeMetric(w, "healthcheck_check_status", name, strconv.Itoa(int(results[name].Status)))
+	}
+
+	writeMetricHeader(w, "healthcheck_check_duration_seconds", "Duration of the last run of the check.")
+	for _, name := range names {
+		writeMetric(w, "healthcheck_check_duration_seconds", name,
+			strconv.FormatFloat(results[name].Duration.Seconds(), 'g', -1, 64))
+	}
+
+	writeMetricHeader(w, "healthcheck_check_last_run_timestamp_seconds", "Unix time at which the last run of the check started.")
+	for _, name := range names {
+		t := results[name].Time
+		writeMetric(w, "healthcheck_check_last_run_timestamp_seconds", name,
+			strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', -1, 64))
+	}
+}
+
+func writeMetricHeader(w *bufio.Writer, metric, help string) {
+	_, _ = w.WriteString("# HELP " + metric + " " + help + "\n")
+	_, _ = w.WriteString("# TYPE " + metric + " gauge\n")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func writeMetric(w *bufio.Writer, metric, name, value string) {
+	_, _ = w.WriteString(metric + `{name="` + labelEscaper.Replace(name) + `"} ` + value + "\n")
+}
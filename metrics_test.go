@@ -0,0 +1,86 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilMetricsChecker, func() {
+			_ = MetricsHandler(nil)
+		})
+	})
+
+	c, err := New(WithHealthChecker("db", new(alwaysHealty)))
+	assert.NoError(t, err)
+	c.CheckHealth(context.Background())
+
+	tests := map[string]struct {
+		accept     string
+		wantType   string
+		wantSuffix string
+	}{
+		"prometheus": {
+			wantType:   textContentType,
+			wantSuffix: "\n",
+		},
+		"openmetrics": {
+			accept:     "application/openmetrics-text; version=1.0.0",
+			wantType:   openMetricsContentType,
+			wantSuffix: "# EOF\n",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.Header.Set("Accept", tc.accept)
+
+			rec := httptest.NewRecorder()
+			MetricsHandler(c).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.wantType, rec.Header().Get("Content-Type"))
+			assert.True(t, strings.HasSuffix(rec.Body.String(), tc.wantSuffix))
+			assert.Contains(t, rec.Body.String(), "healthcheck_status 1\n")
+			assert.Contains(t, rec.Body.String(), `healthcheck_check_status{name="db"} 1`+"\n")
+		})
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	var sb strings.Builder
+	w := bufio.NewWriter(&sb)
+	writeMetrics(w, StatusUnhealthy, map[string]Result{
+		"redis": {Status: StatusHealthy, Duration: 1500 * time.Microsecond, Time: time.Unix(1700000000, 0)},
+		`"db"`:  {Status: StatusUnhealthy, Duration: 2 * time.Second, Time: time.Unix(1700000001, 500000000)},
+	})
+	assert.NoError(t, w.Flush())
+
+	assert.Equal(t, `# HELP healthcheck_status Combined health status of all checks, 1 is healthy, 0 is unknown and -1 is unhealthy.
+# TYPE healthcheck_status gauge
+healthcheck_status -1
+# HELP healthcheck_check_status Health status of the check, 1 is healthy, 0 is unknown and -1 is unhealthy.
+# TYPE healthcheck_check_status gauge
+healthcheck_check_status{name="\"db\""} -1
+healthcheck_check_status{name="redis"} 1
+# HELP healthcheck_check_duration_seconds Duration of the last run of the check.
+# TYPE healthcheck_check_duration_seconds gauge
+healthcheck_check_duration_seconds{name="\"db\""} 2
+healthcheck_check_duration_seconds{name="redis"} 0.0015
+# HELP healthcheck_check_last_run_timestamp_seconds Unix time at which the last run of the check started.
+# TYPE healthcheck_check_last_run_timestamp_seconds gauge
+healthcheck_check_last_run_timestamp_seconds{name="\"db\""} 1700000001.5
+healthcheck_check_last_run_timestamp_seconds{name="redis"} 1700000000
+`, sb.String())
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+// NewSlack creates a [Webhook] which sends notifications to a Slack incoming
+// webhook url, formatted using [SlackPayload].
+func NewSlack(url string, opts ...WebhookOption) *Webhook {
+	return NewWebhook([]string{url}, append(opts, WithPayload(SlackPayload))...)
+}
+
+// NewTeams creates a [Webhook] which sends notifications to a Microsoft Teams
+// incoming webhook url, formatted using [TeamsPayload].
+func NewTeams(url string, opts ...WebhookOption) *Webhook {
+	return NewWebhook([]string{url}, append(opts, WithPayload(TeamsPayload))...)
+}
+
+// SlackPayload encodes a [Notification] as a Slack message with a color-coded
+// attachment, which contains a field for each check.
+func SlackPayload(n Notification) ([]byte, error) {
+	type field struct {
+		Title string `json:"title"`
+		Value string `json:"value"`
+		Short bool   `json:"short"`
+	}
+	type attachment struct {
+		Color     string  `json:"color"`
+		Title     string  `json:"title"`
+		Fallback  string  `json:"fallback"`
+		Fields    []field `json:"fields,omitempty"`
+		Timestamp int64   `json:"ts"`
+	}
+
+	title := n.title()
+	att := attachment{
+		Color:     "#" + color(n.Status),
+		Title:     title,
+		Fallback:  title,
+		Timestamp: n.Time.Unix(),
+	}
+	for _, name := range n.checkNames() {
+		att.Fields = append(att.Fields, field{
+			Title: name,
+			Value: n.Checks[name].String(),
+			Short: true,
+		})
+	}
+
+	b, err := json.Marshal(struct {
+		Attachments []attachment `json:"attachments"`
+	}{Attachments: []attachment{att}})
+	return b, errors.WithStack(err)
+}
+
+// TeamsPayload encodes a [Notification] as a color-coded Microsoft Teams
+// message card, which contains a fact for each check.
+func TeamsPayload(n Notification) ([]byte, error) {
+	type fact struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	type section struct {
+		Facts []fact `json:"facts"`
+	}
+	type card struct {
+		Type       string    `json:"@type"`
+		Context    string    `json:"@context"`
+		ThemeColor string    `json:"themeColor"`
+		Summary    string    `json:"summary"`
+		Title      string    `json:"title"`
+		Sections   []section `json:"sections,omitempty"`
+	}
+
+	title := n.title()
+	c := card{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		ThemeColor: color(n.Status),
+		Summary:    title,
+		Title:      title,
+	}
+	if names := n.checkNames(); len(names) != 0 {
+		facts := make([]fact, 0, len(names))
+		for _, name := range names {
+			facts = append(facts, fact{Name: name, Value: n.Checks[name].String()})
+		}
+		c.Sections = []section{{Facts: facts}}
+	}
+
+	b, err := json.Marshal(c)
+	return b, errors.WithStack(err)
+}
+
+func (n Notification) title() string {
+	return "Health changed from " + n.OldStatus.String() + " to " + n.Status.String()
+}
+
+func (n Notification) checkNames() []string {
+	names := make([]string, 0, len(n.Checks))
+	for name := range n.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// color returns a hex color code, without leading #, which represents stat.
+func color(stat healthcheck.Status) string {
+	switch stat {
+	case healthcheck.StatusHealthy:
+		return "2eb886"
+	case healthcheck.StatusUnhealthy:
+		return "a30200"
+	default:
+		return "daa038"
+	}
+}
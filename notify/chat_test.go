@@ -0,0 +1,75 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+var testNotification = Notification{
+	Status:    healthcheck.StatusUnhealthy,
+	OldStatus: healthcheck.StatusHealthy,
+	Checks: map[string]healthcheck.Status{
+		"redis": healthcheck.StatusHealthy,
+		"db":    healthcheck.StatusUnhealthy,
+	},
+	Time: time.Unix(1700000000, 0),
+}
+
+func TestSlackPayload(t *testing.T) {
+	have, err := SlackPayload(testNotification)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"attachments": [{
+		"color": "#a30200",
+		"title": "Health changed from healthy to unhealthy",
+		"fallback": "Health changed from healthy to unhealthy",
+		"fields": [
+			{"title": "db", "value": "unhealthy", "short": true},
+			{"title": "redis", "value": "healthy", "short": true}
+		],
+		"ts": 1700000000
+	}]}`, string(have))
+}
+
+func TestTeamsPayload(t *testing.T) {
+	have, err := TeamsPayload(testNotification)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"@type": "MessageCard",
+		"@context": "https://schema.org/extensions",
+		"themeColor": "a30200",
+		"summary": "Health changed from healthy to unhealthy",
+		"title": "Health changed from healthy to unhealthy",
+		"sections": [{"facts": [
+			{"name": "db", "value": "unhealthy"},
+			{"name": "redis", "value": "healthy"}
+		]}]
+	}`, string(have))
+}
+
+func TestNewSlack(t *testing.T) {
+	body := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		body <- string(b)
+	}))
+	defer srv.Close()
+
+	w := NewSlack(srv.URL)
+	w.HandleEvent(healthcheck.Event{
+		Kind:      healthcheck.EventHealthChanged,
+		Status:    healthcheck.StatusHealthy,
+		OldStatus: healthcheck.StatusUnknown,
+	})
+	assert.NoError(t, w.Close())
+	assert.Contains(t, <-body, `"color":"#2eb886"`)
+}
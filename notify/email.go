@@ -0,0 +1,163 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"bytes"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+// DefaultUnhealthyFor is the default duration the combined health
+// [healthcheck.Status] should be unhealthy before an [Email] is sent.
+const DefaultUnhealthyFor = 5 * time.Minute
+
+// EmailConfig is the configuration of an [Email] notifier.
+type EmailConfig struct {
+	// Addr is the address of the SMTP server, of form "host:port".
+	Addr string
+	// Auth is used to authenticate with the SMTP server, when not nil.
+	Auth smtp.Auth
+	From string
+	To   []string
+	// UnhealthyFor is the duration the combined health status should be
+	// unhealthy before an alert message is sent. The default is
+	// [DefaultUnhealthyFor].
+	UnhealthyFor time.Duration
+	// Throttle is the minimum duration between two alert messages. Alerts
+	// within this duration are not sent, neither are their recovery messages.
+	Throttle time.Duration
+	// OnError handles errors which occur while sending messages.
+	OnError ErrorHandler
+}
+
+var _ healthcheck.EventSink = (*Email)(nil)
+
+// Email is a [healthcheck.EventSink] which sends an alert message when the
+// combined health [healthcheck.Status] stays unhealthy for longer than a
+// configured duration, and a recovery message when it returns to healthy.
+type Email struct {
+	queue
+	conf     EmailConfig
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+	mut       sync.Mutex
+	timer     *time.Timer
+	last      Notification
+	alerted   bool
+	lastAlert time.Time
+}
+
+// NewEmail creates an [Email] notifier. It starts a goroutine which sends the
+// messages, use [Email.Close] to stop it.
+func NewEmail(conf EmailConfig) *Email {
+	if conf.UnhealthyFor <= 0 {
+		conf.UnhealthyFor = DefaultUnhealthyFor
+	}
+
+	e := Email{
+		conf:     conf,
+		sendMail: smtp.SendMail,
+	}
+	e.onError = conf.OnError
+	e.start(e.send)
+	return &e
+}
+
+// HandleEvent starts a timer when the combined health [healthcheck.Status]
+// changes to unhealthy, which sends an alert message when it expires. The
+// timer is stopped when the status changes before it expires. When the
+// status returns to healthy after an alert is sent, a recovery message is
+// sent.
+func (e *Email) HandleEvent(ev healthcheck.Event) {
+	if ev.Kind != healthcheck.EventHealthChanged {
+		return
+	}
+
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	e.last = NewNotification(ev)
+	if ev.Status == healthcheck.StatusUnhealthy {
+		if e.timer == nil {
+			e.timer = time.AfterFunc(e.conf.UnhealthyFor, e.alert)
+		}
+		return
+	}
+
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	if e.alerted && ev.Status == healthcheck.StatusHealthy {
+		e.alerted = false
+		e.push(e.last)
+	}
+}
+
+func (e *Email) alert() {
+	e.mut.Lock()
+	defer e.mut.Unlock()
+
+	if e.timer == nil {
+		// stopped while waiting for the lock
+		return
+	}
+	e.timer = nil
+
+	if e.conf.Throttle > 0 && !e.lastAlert.IsZero() && time.Since(e.lastAlert) < e.conf.Throttle {
+		return
+	}
+
+	e.alerted = true
+	e.lastAlert = time.Now()
+	e.push(e.last)
+}
+
+func (e *Email) send(n Notification) {
+	if err := e.sendMail(e.conf.Addr, e.conf.Auth, e.conf.From, e.conf.To, e.message(n)); err != nil {
+		e.handleError(errors.Wrap(err, ErrSendFailed))
+	}
+}
+
+func (e *Email) message(n Notification) []byte {
+	subject := "Health recovered"
+	if n.Status == healthcheck.StatusUnhealthy {
+		subject = "Health is unhealthy for more than " + e.conf.UnhealthyFor.String()
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + e.conf.From + "\r\n")
+	buf.WriteString("To: " + strings.Join(e.conf.To, ", ") + "\r\n")
+	buf.WriteString("Subject: " + subject + "\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	buf.WriteString(n.title() + " at " + n.Time.Format(time.RFC3339) + ".\r\n")
+
+	if names := n.checkNames(); len(names) != 0 {
+		buf.WriteString("\r\n")
+		for _, name := range names {
+			buf.WriteString(name + ": " + n.Checks[name].String() + "\r\n")
+		}
+	}
+	return buf.Bytes()
+}
+
+// Close stops a pending alert, stops accepting new notifications and waits
+// until all pending messages are sent.
+func (e *Email) Close() error {
+	e.mut.Lock()
+	if e.timer != nil {
+		e.timer.Stop()
+		e.timer = nil
+	}
+	e.mut.Unlock()
+
+	return e.queue.Close()
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+type mailRecorder struct {
+	mut      sync.Mutex
+	subjects []string
+}
+
+func (r *mailRecorder) sendMail(_ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+	for _, line := range strings.Split(string(msg), "\r\n") {
+		if strings.HasPrefix(line, "Subject: ") {
+			r.mut.Lock()
+			r.subjects = append(r.subjects, strings.TrimPrefix(line, "Subject: "))
+			r.mut.Unlock()
+		}
+	}
+	return nil
+}
+
+func (r *mailRecorder) len() int {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return len(r.subjects)
+}
+
+func changed(stat healthcheck.Status) healthcheck.Event {
+	return healthcheck.Event{
+		Kind:   healthcheck.EventHealthChanged,
+		Status: stat,
+		Time:   time.Now(),
+	}
+}
+
+func newTestEmail(conf EmailConfig) (*Email, *mailRecorder) {
+	var rec mailRecorder
+	e := NewEmail(conf)
+	e.sendMail = rec.sendMail
+	return e, &rec
+}
+
+func TestEmail_HandleEvent(t *testing.T) {
+	t.Run("sustained unhealthy", func(t *testing.T) {
+		e, rec := newTestEmail(EmailConfig{UnhealthyFor: 20 * time.Millisecond})
+
+		e.HandleEvent(changed(healthcheck.StatusUnhealthy))
+		assert.Eventually(t, func() bool { return rec.len() == 1 }, time.Second, time.Millisecond)
+
+		e.HandleEvent(changed(healthcheck.StatusHealthy))
+		assert.NoError(t, e.Close())
+		assert.Equal(t, []string{
+			"Health is unhealthy for more than 20ms",
+			"Health recovered",
+		}, rec.subjects)
+	})
+
+	t.Run("short unhealthy", func(t *testing.T) {
+		e, rec := newTestEmail(EmailConfig{UnhealthyFor: 50 * time.Millisecond})
+
+		e.HandleEvent(changed(healthcheck.StatusUnhealthy))
+		e.HandleEvent(changed(healthcheck.StatusHealthy))
+		time.Sleep(70 * time.Millisecond)
+		assert.NoError(t, e.Close())
+		assert.Empty(t, rec.subjects)
+	})
+
+	t.Run("throttle", func(t *testing.T) {
+		e, rec := newTestEmail(EmailConfig{
+			UnhealthyFor: 10 * time.Millisecond,
+			Throttle:     time.Hour,
+		})
+
+		e.HandleEvent(changed(healthcheck.StatusUnhealthy))
+		assert.Eventually(t, func() bool { return rec.len() == 1 }, time.Second, time.Millisecond)
+		e.HandleEvent(changed(healthcheck.StatusHealthy))
+
+		e.HandleEvent(changed(healthcheck.StatusUnhealthy))
+		time.Sleep(30 * time.Millisecond)
+		e.HandleEvent(changed(healthcheck.StatusHealthy))
+		assert.NoError(t, e.Close())
+
+		assert.Equal(t, []string{
+			"Health is unhealthy for more than 10ms",
+			"Health recovered",
+		}, rec.subjects)
+	})
+}
+
+func TestEmail_message(t *testing.T) {
+	e := Email{conf: EmailConfig{
+		From:         "health@example.org",
+		To:           []string{"ops@example.org", "dev@example.org"},
+		UnhealthyFor: time.Minute,
+	}}
+
+	assert.Equal(t, "From: health@example.org\r\n"+
+		"To: ops@example.org, dev@example.org\r\n"+
+		"Subject: Health is unhealthy for more than 1m0s\r\n"+
+		"Content-Type: text/plain; charset=utf-8\r\n\r\n"+
+		"Health changed from healthy to unhealthy at 2023-11-14T22:13:20Z.\r\n\r\n"+
+		"db: unhealthy\r\n"+
+		"redis: healthy\r\n",
+		string(e.message(Notification{
+			Status:    testNotification.Status,
+			OldStatus: testNotification.OldStatus,
+			Checks:    testNotification.Checks,
+			Time:      testNotification.Time.UTC(),
+		})),
+	)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package notify provides [healthcheck.EventSink](s) which send notifications
+// when the combined health [healthcheck.Status] of a [healthcheck.Checker]
+// changes. Notifications are sent from a separate goroutine, so slow or
+// failing notification targets do not delay health checks.
+package notify
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const ErrQueueFull errors.Msg = "notification queue is full, notification dropped"
+
+// queueSize is the maximum number of pending notifications of a notifier.
+const queueSize = 16
+
+// Notification describes a change of the combined health
+// [healthcheck.Status] of a [healthcheck.Checker].
+type Notification struct {
+	Status    healthcheck.Status            `json:"status"`
+	OldStatus healthcheck.Status            `json:"old_status"`
+	Checks    map[string]healthcheck.Status `json:"checks,omitempty"`
+	Time      time.Time                     `json:"time"`
+}
+
+// NewNotification creates a [Notification] from a
+// [healthcheck.EventHealthChanged] [healthcheck.Event].
+func NewNotification(e healthcheck.Event) Notification {
+	return Notification{
+		Status:    e.Status,
+		OldStatus: e.OldStatus,
+		Checks:    e.Checks,
+		Time:      e.Time,
+	}
+}
+
+// ErrorHandler handles errors which occur while sending notifications.
+type ErrorHandler func(err error)
+
+// queue sends notifications in order from a separate goroutine.
+type queue struct {
+	send    func(n Notification)
+	onError ErrorHandler
+
+	mut    sync.Mutex
+	closed bool
+	ch     chan Notification
+	done   chan struct{}
+}
+
+func (q *queue) start(send func(n Notification)) {
+	q.send = send
+	q.ch = make(chan Notification, queueSize)
+	q.done = make(chan struct{})
+
+	go func() {
+		defer close(q.done)
+		for n := range q.ch {
+			q.send(n)
+		}
+	}()
+}
+
+func (q *queue) push(n Notification) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	if q.closed {
+		return
+	}
+
+	select {
+	case q.ch <- n:
+	default:
+		q.handleError(errors.New(ErrQueueFull))
+	}
+}
+
+func (q *queue) handleError(err error) {
+	if q.onError != nil {
+		q.onError(err)
+	}
+}
+
+// Close stops accepting new notifications and waits until all pending
+// notifications are sent.
+func (q *queue) Close() error {
+	q.mut.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.ch)
+	}
+	q.mut.Unlock()
+
+	<-q.done
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+)
+
+const (
+	ErrSendFailed           errors.Msg = "failed to send notification"
+	ErrUnexpectedStatusCode errors.Msg = "unexpected status code"
+)
+
+// DefaultTimeout is the default timeout of a single webhook request.
+const DefaultTimeout = 10 * time.Second
+
+// PayloadFunc encodes a [Notification] as the body of a webhook request.
+type PayloadFunc func(n Notification) ([]byte, error)
+
+// JSONPayload encodes a [Notification] as json.
+func JSONPayload(n Notification) ([]byte, error) {
+	b, err := json.Marshal(n)
+	return b, errors.WithStack(err)
+}
+
+var _ healthcheck.EventSink = (*Webhook)(nil)
+
+// Webhook is a [healthcheck.EventSink] which POSTs a payload to one or more
+// urls when the combined health [healthcheck.Status] changes.
+type Webhook struct {
+	queue
+	urls       []string
+	httpClient *http.Client
+	header     http.Header
+	payload    PayloadFunc
+	timeout    time.Duration
+	attempts   int
+	backoff    time.Duration
+}
+
+// WebhookOption is an option for a [Webhook] created with [NewWebhook].
+type WebhookOption func(w *Webhook)
+
+// WithHTTPClient sets the [http.Client] used to perform requests. The default
+// is [http.DefaultClient].
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(w *Webhook) { w.httpClient = client }
+}
+
+// WithHeader adds a header to the requests, e.g. for authentication.
+func WithHeader(key, value string) WebhookOption {
+	return func(w *Webhook) {
+		if w.header == nil {
+			w.header = make(http.Header, 2)
+		}
+		w.header.Add(key, value)
+	}
+}
+
+// WithPayload sets the [PayloadFunc] used to encode the body of the requests.
+// The default is [JSONPayload].
+func WithPayload(fn PayloadFunc) WebhookOption {
+	return func(w *Webhook) { w.payload = fn }
+}
+
+// WithTimeout sets the timeout of a single request. The default is
+// [DefaultTimeout].
+func WithTimeout(timeout time.Duration) WebhookOption {
+	return func(w *Webhook) { w.timeout = timeout }
+}
+
+// WithRetry retries failed requests until a total of attempts requests are
+// made. Before each retry the [Webhook] waits for backoff, which doubles
+// after each retry.
+func WithRetry(attempts int, backoff time.Duration) WebhookOption {
+	return func(w *Webhook) {
+		w.attempts, w.backoff = attempts, backoff
+	}
+}
+
+// WithErrorHandler sets the [ErrorHandler] which handles errors that occur
+// while sending notifications. By default, errors are ignored.
+func WithErrorHandler(fn ErrorHandler) WebhookOption {
+	return func(w *Webhook) { w.onError = fn }
+}
+
+// NewWebhook creates a [Webhook] which POSTs a json payload to urls when the
+// combined health [healthcheck.Status] changes. It starts a goroutine which
+// sends the notifications, use [Webhook.Close] to stop it.
+func NewWebhook(urls []string, opts ...WebhookOption) *Webhook {
+	w := Webhook{
+		urls:    urls,
+		payload: JSONPayload,
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&w)
+		}
+	}
+	if w.httpClient == nil {
+		w.httpClient = http.DefaultClient
+	}
+
+	w.start(w.notify)
+	return &w
+}
+
+// HandleEvent queues a [Notification] for each
+// [healthcheck.EventHealthChanged] [healthcheck.Event]. Other events are
+// ignored.
+func (w *Webhook) HandleEvent(e healthcheck.Event) {
+	if e.Kind == healthcheck.EventHealthChanged {
+		w.push(NewNotification(e))
+	}
+}
+
+func (w *Webhook) notify(n Notification) {
+	body, err := w.payload(n)
+	if err != nil {
+		w.handleError(errors.Wrap(err, ErrSendFailed))
+		return
+	}
+
+	for _, url := range w.urls {
+		if err = w.send(url, body); err != nil {
+			w.handleError(errors.Wrapf(err, "url %s", url))
+		}
+	}
+}
+
+func (w *Webhook) send(url string, body []byte) error {
+	backoff := w.backoff
+	for attempt := 1; ; attempt++ {
+		err := w.post(url, body)
+		if err == nil || attempt >= w.attempts {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (w *Webhook) post(url string, body []byte) error {
+	ctx := context.Background()
+	if w.timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, w.timeout)
+		defer cancelFn()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, ErrSendFailed)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, vals := range w.header {
+		req.Header[key] = append(req.Header[key], vals...)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, ErrSendFailed)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Wrapf(ErrUnexpectedStatusCode, "status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_HandleEvent(t *testing.T) {
+	var calls atomic.Int32
+	bodies := make(chan []byte, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+		if calls.Add(1) == 1 {
+			wri.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		bodies <- body
+	}))
+	defer srv.Close()
+
+	var errs []error
+	w := NewWebhook([]string{srv.URL},
+		WithHeader("Authorization", "Bearer secret"),
+		WithRetry(2, time.Millisecond),
+		WithErrorHandler(func(err error) { errs = append(errs, err) }),
+	)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	w.HandleEvent(healthcheck.Event{Kind: healthcheck.EventCheckCompleted})
+	w.HandleEvent(healthcheck.Event{
+		Kind:      healthcheck.EventHealthChanged,
+		Status:    healthcheck.StatusUnhealthy,
+		OldStatus: healthcheck.StatusHealthy,
+		Checks:    map[string]healthcheck.Status{"db": healthcheck.StatusUnhealthy},
+		Time:      now,
+	})
+	assert.NoError(t, w.Close())
+	assert.Empty(t, errs)
+	assert.Equal(t, int32(2), calls.Load(), "should retry once")
+
+	var have Notification
+	assert.NoError(t, json.Unmarshal(<-bodies, &have))
+	assert.Equal(t, Notification{
+		Status:    healthcheck.StatusUnhealthy,
+		OldStatus: healthcheck.StatusHealthy,
+		Checks:    map[string]healthcheck.Status{"db": healthcheck.StatusUnhealthy},
+		Time:      now,
+	}, have)
+}
+
+func TestWebhook_errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(wri http.ResponseWriter, _ *http.Request) {
+		wri.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var errs []error
+	w := NewWebhook([]string{srv.URL}, WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	w.HandleEvent(healthcheck.Event{Kind: healthcheck.EventHealthChanged})
+	assert.NoError(t, w.Close())
+
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrUnexpectedStatusCode)
+
+	// events after close are ignored
+	w.HandleEvent(healthcheck.Event{Kind: healthcheck.EventHealthChanged})
+	assert.NoError(t, w.Close())
+}
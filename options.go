@@ -19,6 +19,27 @@ func WithLogger(log Logger) Option {
 	}
 }
 
+const panicNilCheckLogger = "healthcheck.WithCheckLogger: Logger should not be nil"
+
+// WithCheckLogger sets a [Logger] which logs the status changes of only the
+// [HealthChecker] registered with name. Use [NewSlogLogger] to log using a
+// [slog.Logger]. The status of this [HealthChecker] is omitted from the
+// statuses logged by the [Checker]'s own [Logger], so noisy or sensitive
+// checks can be logged to a different destination or level.
+func WithCheckLogger(name string, log Logger) Option {
+	if log == nil {
+		panic(panicNilCheckLogger)
+	}
+
+	return func(c *Checker) error {
+		if c.checkLogs == nil {
+			c.checkLogs = make(map[string]Logger, 2)
+		}
+		c.checkLogs[name] = log
+		return nil
+	}
+}
+
 func WithDefaultLogger() Option { return WithLogger(DefaultLogger()) }
 
 func WithHealthChecker(name string, check HealthChecker) Option {
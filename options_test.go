@@ -50,3 +50,50 @@ var _ HealthChecker = (*alwaysHealty)(nil)
 type alwaysHealty struct{}
 
 func (alwaysHealty) CheckHealth(context.Context) Status { return StatusHealthy }
+
+type logCall struct {
+	status, oldStatus Status
+	statuses          map[string]Status
+}
+
+type recordLogger struct{ calls []logCall }
+
+func (l *recordLogger) LogHealthChanged(status, oldStatus Status, statuses map[string]Status) {
+	l.calls = append(l.calls, logCall{status, oldStatus, statuses})
+}
+
+func TestWithCheckLogger(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilCheckLogger, func() {
+			_ = WithCheckLogger("test", nil)
+		})
+	})
+
+	t.Run("scoped", func(t *testing.T) {
+		var checkerLog, dbLog recordLogger
+		db := &errorCheck{stat: StatusHealthy}
+
+		c, err := New(
+			WithLogger(&checkerLog),
+			WithCheckLogger("db", &dbLog),
+			WithHealthChecker("db", db),
+			WithHealthChecker("api", new(alwaysHealty)),
+		)
+		assert.NoError(t, err)
+
+		c.CheckHealth(context.Background())
+		c.CheckHealth(context.Background())
+		db.stat = StatusUnhealthy
+		c.CheckHealth(context.Background())
+
+		assert.Equal(t, []logCall{
+			{StatusHealthy, StatusUnknown, map[string]Status{"db": StatusHealthy}},
+			{StatusUnhealthy, StatusHealthy, map[string]Status{"db": StatusUnhealthy}},
+		}, dbLog.calls)
+
+		assert.Len(t, checkerLog.calls, 2)
+		for _, call := range checkerLog.calls {
+			assert.Equal(t, map[string]Status{"api": StatusHealthy}, call.statuses)
+		}
+	})
+}
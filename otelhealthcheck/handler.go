@@ -0,0 +1,53 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otelhealthcheck
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/go-pogo/healthcheck"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	RouteKey      = attribute.Key("healthcheck.route")
+	StatusCodeKey = attribute.Key("http.response.status_code")
+)
+
+type handlerRecorder struct {
+	duration metric.Float64Histogram
+}
+
+// NewHandlerRecorder returns a [healthcheck.HandlerRecorder] which records
+// the duration of each request handled by a [healthcheck.HTTPHandler] in
+// seconds, as a histogram metric named "healthcheck.handler.duration", using
+// a [metric.Meter] from mp. The histogram's count provides the number of
+// requests, labeled with the route and response status code.
+func NewHandlerRecorder(mp metric.MeterProvider) (healthcheck.HandlerRecorder, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	hist, err := mp.Meter(ScopeName).Float64Histogram(
+		"healthcheck.handler.duration",
+		metric.WithDescription("Duration of handling health check requests."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &handlerRecorder{duration: hist}, nil
+}
+
+func (r *handlerRecorder) RecordHandle(route healthcheck.HandlerRoute, code int, dur time.Duration) {
+	r.duration.Record(context.Background(), dur.Seconds(), metric.WithAttributes(
+		RouteKey.String(string(route)),
+		StatusCodeKey.Int(code),
+	))
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otelhealthcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestNewHandlerRecorder(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rec, err := NewHandlerRecorder(mp)
+	assert.NoError(t, err)
+
+	h := healthcheck.HTTPHandler(healthcheck.HealthCheckerFunc(func(context.Context) healthcheck.Status {
+		return healthcheck.StatusHealthy
+	}), healthcheck.WithHandlerRecorder(rec))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, healthcheck.PathPattern, nil))
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Len(t, rm.ScopeMetrics, 1)
+
+	m := rm.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "healthcheck.handler.duration", m.Name)
+
+	hist := m.Data.(metricdata.Histogram[float64])
+	assert.Len(t, hist.DataPoints, 1)
+	assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+	assert.Equal(t, attribute.NewSet(
+		RouteKey.String("health"),
+		StatusCodeKey.Int(http.StatusOK),
+	), hist.DataPoints[0].Attributes)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package otelhealthcheck provides OpenTelemetry instrumentation for
+// [healthcheck.Checker]. Each health check run, and each individual
+// [healthcheck.HealthChecker] within it, is wrapped in a span. This makes slow
+// health checks visible in traces and links probe latency to dependency
+// latency. Requests handled by a [healthcheck.HTTPHandler] can be recorded as
+// metrics using [NewHandlerRecorder].
+package otelhealthcheck
+
+import (
+	"context"
+
+	"github.com/go-pogo/healthcheck"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name.
+const ScopeName = "github.com/go-pogo/healthcheck/otelhealthcheck"
+
+const (
+	NameKey   = attribute.Key("healthcheck.name")
+	StatusKey = attribute.Key("healthcheck.status")
+)
+
+// WithTracing returns a [healthcheck.Option] which wraps each run of
+// [healthcheck.Checker.CheckHealth] in a span named "healthcheck.CheckHealth",
+// and each registered [healthcheck.HealthChecker] in a child span named
+// "healthcheck.Check". Without [Option](s), the global
+// [otel.GetTracerProvider] is used.
+func WithTracing(opts ...Option) healthcheck.Option {
+	var conf config
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&conf)
+		}
+	}
+	if conf.tracerProvider == nil {
+		conf.tracerProvider = otel.GetTracerProvider()
+	}
+
+	tracer := conf.tracerProvider.Tracer(ScopeName)
+	return healthcheck.WithInterceptor(func(ctx context.Context, name string, next healthcheck.CheckFunc) (healthcheck.Status, error) {
+		spanName, attrs := "healthcheck.CheckHealth", []attribute.KeyValue(nil)
+		if name != "" {
+			spanName, attrs = "healthcheck.Check", []attribute.KeyValue{NameKey.String(name)}
+		}
+
+		ctx, span := tracer.Start(ctx, spanName, trace.WithAttributes(attrs...))
+		defer span.End()
+
+		stat, err := next(ctx)
+		span.SetAttributes(StatusKey.String(stat.String()))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if stat != healthcheck.StatusHealthy {
+			span.SetStatus(codes.Error, stat.String())
+		}
+		return stat, err
+	})
+}
+
+type config struct {
+	tracerProvider trace.TracerProvider
+}
+
+type Option func(c *config)
+
+// WithTracerProvider sets the [trace.TracerProvider] used to create spans.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
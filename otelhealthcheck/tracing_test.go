@@ -0,0 +1,55 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package otelhealthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-pogo/healthcheck"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type errorCheck struct{ err error }
+
+func (c *errorCheck) CheckHealth(ctx context.Context) healthcheck.Status {
+	stat, _ := c.Check(ctx)
+	return stat
+}
+
+func (c *errorCheck) Check(context.Context) (healthcheck.Status, error) {
+	return healthcheck.StatusUnhealthy, c.err
+}
+
+func TestWithTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+
+	c, err := healthcheck.New(
+		WithTracing(WithTracerProvider(tp)),
+		healthcheck.WithHealthChecker("db", &errorCheck{err: errors.New("connection refused")}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, healthcheck.StatusUnhealthy, c.CheckHealth(context.Background()))
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 2)
+
+	check, run := ended[0], ended[1]
+	assert.Equal(t, "healthcheck.Check", check.Name())
+	assert.Contains(t, check.Attributes(), NameKey.String("db"))
+	assert.Contains(t, check.Attributes(), StatusKey.String("unhealthy"))
+	assert.Equal(t, codes.Error, check.Status().Code)
+	assert.Equal(t, "connection refused", check.Status().Description)
+	assert.Len(t, check.Events(), 1, "error should be recorded")
+
+	assert.Equal(t, "healthcheck.CheckHealth", run.Name())
+	assert.Equal(t, run.SpanContext().SpanID(), check.Parent().SpanID())
+	assert.Contains(t, run.Attributes(), StatusKey.String("unhealthy"))
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-pogo/errors"
+)
+
+const ErrPublishQueueFull errors.Msg = "publish queue is full, event dropped"
+
+// publishQueueSize is the maximum number of pending events of a [Publisher].
+const publishQueueSize = 16
+
+// Publisher publishes [Event](s) to a message bus, like NATS, Kafka or Redis
+// pub/sub, enabling fleet-wide health streams.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// PublisherFunc publishes [Event](s) to a message bus.
+type PublisherFunc func(ctx context.Context, e Event) error
+
+func (fn PublisherFunc) Publish(ctx context.Context, e Event) error { return fn(ctx, e) }
+
+const panicNilPublisher = "healthcheck.WithPublisher: Publisher should not be nil"
+
+// WithPublisher adds a [Publisher] to the [Checker], which publishes each
+// [EventHealthChanged] [Event]. Events are published in order from a
+// separate goroutine, each within the [Checker]'s Timeout, so a slow or
+// unreachable message bus does not delay health checks. When too many events
+// are pending, new events are dropped and an error wrapping
+// [ErrPublishQueueFull] is passed to onError. Errors returned by the
+// [Publisher] are also passed to onError, when it is not nil. Use
+// [Checker.Close] to wait for pending events to be published.
+func WithPublisher(p Publisher, onError func(err error)) Option {
+	if p == nil {
+		panic(panicNilPublisher)
+	}
+
+	return func(c *Checker) error {
+		ps := &publisherSink{
+			checker:   c,
+			publisher: p,
+			onError:   onError,
+			ch:        make(chan Event, publishQueueSize),
+			done:      make(chan struct{}),
+		}
+		go ps.run()
+
+		c.sinks = append(c.sinks, ps)
+		return nil
+	}
+}
+
+type publisherSink struct {
+	checker   *Checker
+	publisher Publisher
+	onError   func(err error)
+
+	mut    sync.Mutex
+	closed bool
+	ch     chan Event
+	done   chan struct{}
+}
+
+func (ps *publisherSink) HandleEvent(e Event) {
+	if e.Kind != EventHealthChanged {
+		return
+	}
+
+	ps.mut.Lock()
+	defer ps.mut.Unlock()
+	if ps.closed {
+		return
+	}
+
+	select {
+	case ps.ch <- e:
+	default:
+		ps.handleError(errors.New(ErrPublishQueueFull))
+	}
+}
+
+func (ps *publisherSink) run() {
+	defer close(ps.done)
+	for e := range ps.ch {
+		ps.publish(e)
+	}
+}
+
+func (ps *publisherSink) publish(e Event) {
+	ctx := context.Background()
+	if ps.checker.Timeout > 0 {
+		var cancelFn context.CancelFunc
+		ctx, cancelFn = context.WithTimeout(ctx, ps.checker.Timeout)
+		defer cancelFn()
+	}
+
+	if err := ps.publisher.Publish(ctx, e); err != nil {
+		ps.handleError(err)
+	}
+}
+
+func (ps *publisherSink) handleError(err error) {
+	if ps.onError != nil {
+		ps.onError(err)
+	}
+}
+
+// Close stops accepting new events and waits until all pending events are
+// published.
+func (ps *publisherSink) Close() error {
+	ps.mut.Lock()
+	if !ps.closed {
+		ps.closed = true
+		close(ps.ch)
+	}
+	ps.mut.Unlock()
+
+	<-ps.done
+	return nil
+}
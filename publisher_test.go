@@ -0,0 +1,96 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-pogo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithPublisher(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.PanicsWithValue(t, panicNilPublisher, func() {
+			_ = WithPublisher(nil, nil)
+		})
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		wantErr := errors.New("not connected")
+
+		var published []Event
+		var haveErr error
+		c, err := New(
+			WithPublisher(PublisherFunc(func(ctx context.Context, e Event) error {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline)
+
+				published = append(published, e)
+				return wantErr
+			}), func(err error) { haveErr = err }),
+			WithHealthChecker("db", &errorCheck{stat: StatusHealthy}),
+		)
+		assert.NoError(t, err)
+
+		assert.Equal(t, StatusHealthy, c.CheckHealth(context.Background()))
+		assert.Equal(t, StatusHealthy, c.CheckHealth(context.Background()))
+		assert.NoError(t, c.Close())
+
+		assert.Len(t, published, 1, "should only publish status changes")
+		assert.Equal(t, EventHealthChanged, published[0].Kind)
+		assert.Equal(t, StatusHealthy, published[0].Status)
+		assert.Equal(t, map[string]Status{"db": StatusHealthy}, published[0].Checks)
+		assert.Same(t, wantErr, haveErr)
+	})
+
+	t.Run("blocking publisher", func(t *testing.T) {
+		unblock := make(chan struct{})
+		var mut sync.Mutex
+		var errs []error
+
+		check := &errorCheck{stat: StatusHealthy}
+		c, err := New(
+			WithPublisher(PublisherFunc(func(ctx context.Context, _ Event) error {
+				<-unblock
+				return nil
+			}), func(err error) {
+				mut.Lock()
+				errs = append(errs, err)
+				mut.Unlock()
+			}),
+			WithHealthChecker("db", check),
+		)
+		assert.NoError(t, err)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < publishQueueSize+5; i++ {
+				check.stat = -check.stat
+				c.CheckHealth(context.Background())
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("CheckHealth is blocked by Publisher")
+		}
+
+		close(unblock)
+		assert.NoError(t, c.Close())
+
+		mut.Lock()
+		defer mut.Unlock()
+		assert.NotEmpty(t, errs)
+		for _, err := range errs {
+			assert.ErrorIs(t, err, ErrPublishQueueFull)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ HealthChecker = (*RateLimitedChecker)(nil)
+
+// RateLimitedChecker wraps a [HealthChecker] and enforces a minimum interval
+// between calls to it.
+type RateLimitedChecker struct {
+	HealthChecker
+	MinInterval time.Duration
+
+	mut     sync.Mutex
+	stat    Status
+	started time.Time
+	running bool
+}
+
+// RateLimited wraps check in a [RateLimitedChecker] which calls check at most
+// once per minInterval, regardless of how often CheckHealth is called. In
+// between, the previous [Status] of check is returned.
+func RateLimited(check HealthChecker, minInterval time.Duration) *RateLimitedChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	return &RateLimitedChecker{
+		HealthChecker: check,
+		MinInterval:   minInterval,
+	}
+}
+
+// CheckHealth calls the wrapped [HealthChecker] when at least MinInterval has
+// passed since the start of the previous call. Otherwise, or while another
+// call is still running, it immediately returns the previous [Status]. Unlike
+// [CachedChecker], concurrent calls never wait for the wrapped
+// [HealthChecker].
+func (rc *RateLimitedChecker) CheckHealth(ctx context.Context) Status {
+	rc.mut.Lock()
+	if rc.running || (!rc.started.IsZero() && time.Since(rc.started) < rc.MinInterval) {
+		defer rc.mut.Unlock()
+		return rc.stat
+	}
+
+	rc.running = true
+	rc.started = time.Now()
+	rc.mut.Unlock()
+
+	stat := StatusUnknown
+	defer func() {
+		// also reset running when the wrapped HealthChecker panics
+		rc.mut.Lock()
+		rc.stat = stat
+		rc.running = false
+		rc.mut.Unlock()
+	}()
+
+	stat = rc.HealthChecker.CheckHealth(ctx)
+	return stat
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimited(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		RateLimited(nil, time.Second)
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		var calls atomic.Int32
+		rc := RateLimited(HealthCheckerFunc(func(context.Context) Status {
+			if calls.Add(1) == 1 {
+				return StatusHealthy
+			}
+			return StatusUnhealthy
+		}), 50*time.Millisecond)
+
+		ctx := context.Background()
+		assert.Equal(t, StatusHealthy, rc.CheckHealth(ctx))
+		assert.Equal(t, StatusHealthy, rc.CheckHealth(ctx))
+		assert.Equal(t, int32(1), calls.Load())
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, StatusUnhealthy, rc.CheckHealth(ctx))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("panic", func(t *testing.T) {
+		var calls atomic.Int32
+		rc := RateLimited(HealthCheckerFunc(func(context.Context) Status {
+			if calls.Add(1) == 1 {
+				panic("boom")
+			}
+			return StatusHealthy
+		}), 0)
+
+		assert.Panics(t, func() { rc.CheckHealth(context.Background()) })
+		assert.Equal(t, StatusHealthy, rc.CheckHealth(context.Background()))
+		assert.Equal(t, int32(2), calls.Load())
+	})
+	t.Run("running", func(t *testing.T) {
+		started, release := make(chan struct{}), make(chan struct{})
+		rc := RateLimited(HealthCheckerFunc(func(context.Context) Status {
+			close(started)
+			<-release
+			return StatusHealthy
+		}), 0)
+
+		ctx := context.Background()
+		done := make(chan Status)
+		go func() { done <- rc.CheckHealth(ctx) }()
+
+		<-started
+		assert.Equal(t, StatusUnknown, rc.CheckHealth(ctx), "should not wait for running check")
+		close(release)
+		assert.Equal(t, StatusHealthy, <-done)
+	})
+}
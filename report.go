@@ -0,0 +1,12 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+// Report contains the overall [Status] of a service and, when available, the
+// statuses of its individual [HealthChecker](s).
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Status `json:"checks,omitempty"`
+}
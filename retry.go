@@ -0,0 +1,58 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+var _ HealthChecker = (*RetryChecker)(nil)
+
+// RetryChecker wraps a [HealthChecker] and retries it when it does not return
+// [StatusHealthy].
+type RetryChecker struct {
+	HealthChecker
+	Attempts int
+	Backoff  time.Duration
+}
+
+// Retry wraps check in a [RetryChecker] which calls check until it returns
+// [StatusHealthy], a total of attempts calls are made, or the context's
+// deadline is exceeded. Before each retry it waits for backoff, which doubles
+// after each retry. This smooths over one-off transient errors of an
+// individual [HealthChecker].
+func Retry(check HealthChecker, attempts int, backoff time.Duration) *RetryChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	return &RetryChecker{
+		HealthChecker: check,
+		Attempts:      attempts,
+		Backoff:       backoff,
+	}
+}
+
+// CheckHealth calls the wrapped [HealthChecker] until it returns
+// [StatusHealthy] or no attempts are left. It returns the [Status] of the
+// last call.
+func (rc *RetryChecker) CheckHealth(ctx context.Context) Status {
+	backoff := rc.Backoff
+	for attempt := 1; ; attempt++ {
+		stat := rc.HealthChecker.CheckHealth(ctx)
+		if stat == StatusHealthy || attempt >= rc.Attempts {
+			return stat
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return stat
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		Retry(nil, 3, time.Millisecond)
+	})
+
+	// sequence returns a HealthChecker which returns the statuses in order,
+	// and a pointer to the number of calls made
+	sequence := func(stats ...Status) (HealthChecker, *int) {
+		var calls int
+		return HealthCheckerFunc(func(context.Context) Status {
+			stat := stats[calls]
+			calls++
+			return stat
+		}), &calls
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		check, calls := sequence(StatusHealthy)
+		assert.Equal(t, StatusHealthy, Retry(check, 3, time.Millisecond).CheckHealth(context.Background()))
+		assert.Equal(t, 1, *calls)
+	})
+	t.Run("recovers", func(t *testing.T) {
+		check, calls := sequence(StatusUnhealthy, StatusUnknown, StatusHealthy)
+		assert.Equal(t, StatusHealthy, Retry(check, 3, time.Millisecond).CheckHealth(context.Background()))
+		assert.Equal(t, 3, *calls)
+	})
+	t.Run("attempts exceeded", func(t *testing.T) {
+		check, calls := sequence(StatusUnhealthy, StatusUnhealthy, StatusHealthy)
+		assert.Equal(t, StatusUnhealthy, Retry(check, 2, time.Millisecond).CheckHealth(context.Background()))
+		assert.Equal(t, 2, *calls)
+	})
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancelFn := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancelFn()
+
+		check, calls := sequence(StatusUnknown, StatusHealthy)
+		assert.Equal(t, StatusUnknown, Retry(check, 2, time.Second).CheckHealth(ctx))
+		assert.Equal(t, 1, *calls)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"net/http"
+)
+
+// Route describes a http route which serves the health of a [Checker].
+type Route struct {
+	Name    string
+	Method  string
+	Pattern string
+	Handler http.Handler
+}
+
+// Router registers a [http.Handler] for a pattern, like [http.ServeMux] and
+// most third party routers do.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+const panicNilRoutesChecker = "healthcheck.Routes: Checker should not be nil"
+
+// Routes returns the [Route](s) which serve the health of checker at their
+// default paths: [PathPattern] for the combined health [Status] and
+// [PathPattern] + "/" for the status of the individual [HealthChecker](s),
+// see [WithCheckRoutes]. Both routes share the same [http.Handler], created
+// with [HTTPHandler] and opts.
+//
+// Routes does not depend on any specific server or router, so this package
+// does not need to import them. Convert the returned [Route](s) to register
+// them with servers that use their own route type, like the route
+// registration of github.com/go-pogo/serv, or use [RegisterRoutes] for a
+// [Router].
+func Routes(checker *Checker, opts ...HandlerOption) []Route {
+	if checker == nil {
+		panic(panicNilRoutesChecker)
+	}
+
+	h := HTTPHandler(checker, append([]HandlerOption{WithCheckRoutes(PathPattern)}, opts...)...)
+	return []Route{
+		{Name: "healthcheck", Method: http.MethodGet, Pattern: PathPattern, Handler: h},
+		{Name: "healthcheck_check", Method: http.MethodGet, Pattern: PathPattern + "/", Handler: h},
+	}
+}
+
+// RegisterRoutes registers the [Route](s) of checker, see [Routes], to r.
+//
+//	healthcheck.RegisterRoutes(http.DefaultServeMux, checker)
+func RegisterRoutes(r Router, checker *Checker, opts ...HandlerOption) {
+	for _, route := range Routes(checker, opts...) {
+		r.Handle(route.Pattern, route.Handler)
+	}
+}
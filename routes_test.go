@@ -0,0 +1,47 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutes(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilRoutesChecker, func() {
+		_ = Routes(nil)
+	})
+}
+
+func TestRegisterRoutes(t *testing.T) {
+	checker, err := New(
+		WithHealthChecker("db", new(alwaysHealty)),
+		WithHealthChecker("cache", staticStatus(StatusUnhealthy)),
+	)
+	assert.NoError(t, err)
+
+	mux := http.NewServeMux()
+	RegisterRoutes(mux, checker, WithAlwaysOK())
+
+	tests := map[string]struct {
+		target     string
+		wantStatus string
+	}{
+		"all":     {target: PathPattern, wantStatus: "unhealthy"},
+		"healthy": {target: PathPattern + "/db", wantStatus: "healthy"},
+		"failing": {target: PathPattern + "/cache", wantStatus: "unhealthy"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.target, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.wantStatus, rec.Header().Get(StatusHeader))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"time"
+)
+
+var _ HealthChecker = (*TimeoutChecker)(nil)
+
+// TimeoutChecker wraps a [HealthChecker] with its own timeout, independent of
+// the [Checker]'s Timeout.
+type TimeoutChecker struct {
+	HealthChecker
+	Timeout time.Duration
+	// TimeoutStatus is the [Status] returned when the [HealthChecker] does
+	// not finish within Timeout. Its zero value is [StatusUnknown].
+	TimeoutStatus Status
+}
+
+// WithTimeoutChecker wraps check in a [TimeoutChecker] which cancels the
+// context passed to check after d. When check does not return in time,
+// [StatusUnknown] is returned. Set [TimeoutChecker.TimeoutStatus] to
+// [StatusUnhealthy] to consider a timeout unhealthy instead.
+func WithTimeoutChecker(check HealthChecker, d time.Duration) *TimeoutChecker {
+	if check == nil {
+		panic(panicNilHealthChecker)
+	}
+	return &TimeoutChecker{
+		HealthChecker: check,
+		Timeout:       d,
+	}
+}
+
+// CheckHealth calls the wrapped [HealthChecker] with a context which is
+// canceled after Timeout. It returns TimeoutStatus when the wrapped
+// [HealthChecker] does not return in time. A Timeout of zero or less means
+// no timeout.
+func (tc *TimeoutChecker) CheckHealth(ctx context.Context) Status {
+	if tc.Timeout <= 0 {
+		return tc.HealthChecker.CheckHealth(ctx)
+	}
+
+	ctx, cancelFn := context.WithTimeout(ctx, tc.Timeout)
+	defer cancelFn()
+
+	// buffered, so the goroutine does not leak when the wrapped HealthChecker
+	// returns after the timeout
+	res := make(chan Status, 1)
+	go func() { res <- tc.HealthChecker.CheckHealth(ctx) }()
+
+	select {
+	case stat := <-res:
+		return stat
+	case <-ctx.Done():
+		return tc.TimeoutStatus
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTimeoutChecker(t *testing.T) {
+	assert.PanicsWithValue(t, panicNilHealthChecker, func() {
+		WithTimeoutChecker(nil, time.Second)
+	})
+
+	blocking := HealthCheckerFunc(func(context.Context) Status {
+		time.Sleep(time.Second)
+		return StatusHealthy
+	})
+
+	tests := map[string]struct {
+		checker *TimeoutChecker
+		want    Status
+	}{
+		"in time": {
+			checker: WithTimeoutChecker(HealthCheckerFunc(func(context.Context) Status {
+				return StatusHealthy
+			}), time.Second),
+			want: StatusHealthy,
+		},
+		"timeout": {
+			checker: WithTimeoutChecker(blocking, 10*time.Millisecond),
+			want:    StatusUnknown,
+		},
+		"timeout status": {
+			checker: &TimeoutChecker{
+				HealthChecker: blocking,
+				Timeout:       10 * time.Millisecond,
+				TimeoutStatus: StatusUnhealthy,
+			},
+			want: StatusUnhealthy,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.checker.CheckHealth(context.Background()))
+		})
+	}
+}